@@ -6,6 +6,8 @@
 package binaryop
 
 import (
+	"log"
+
 	"github.com/rossmerr/graphblas/constraints"
 )
 
@@ -30,6 +32,20 @@ func NewMonoID[T constraints.None](zero T, operator BinaryOp[T]) MonoID[T] {
 	return &monoID[T]{unit: zero, BinaryOp: operator}
 }
 
+// Operator the binary operation the monoID folds with, every MonoID from NewMonoID has one, any other MonoID
+// must also implement BinaryOp to be used where single pairs are combined, such as the addition of a Semiring
+func Operator[T constraints.None](s MonoID[T]) BinaryOp[T] {
+	switch operator := s.(type) {
+	case *monoID[T]:
+		return operator.BinaryOp
+	case BinaryOp[T]:
+		return operator
+	}
+
+	log.Panicf("MonoID %T has no binary operation", s)
+	return nil
+}
+
 // Reduce left folding over the monoID
 func (s *monoID[T]) Reduce(done <-chan struct{}, slice <-chan T) <-chan T {
 	out := make(chan T)
@@ -36,3 +36,11 @@ func Test_Reduce(t *testing.T) {
 		}
 	}
 }
+
+func Test_Operator(t *testing.T) {
+	monoID := binaryop.NewMonoID(0, binaryop.Maximum[int]())
+
+	if result := binaryop.Operator(monoID).Apply(2, 3); result != 3 {
+		t.Errorf("Operator Apply = %+v, want %+v", result, 3)
+	}
+}
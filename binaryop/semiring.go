@@ -0,0 +1,36 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package binaryop
+
+import "github.com/rossmerr/graphblas/constraints"
+
+// Semiring is a MonoID used to add together with a BinaryOp used to multiply
+type Semiring[T constraints.None] interface {
+	// Add the monoid the products are combined with, its zero is the value of an empty sum
+	Add() MonoID[T]
+
+	// Multiply the operator applied to each pair of elements
+	Multiply() BinaryOp[T]
+}
+
+type semiring[T constraints.None] struct {
+	add      MonoID[T]
+	multiply BinaryOp[T]
+}
+
+// NewSemiring returns a Semiring, add must have a binary operation as described by Operator
+func NewSemiring[T constraints.None](add MonoID[T], multiply BinaryOp[T]) Semiring[T] {
+	Operator(add)
+	return &semiring[T]{add: add, multiply: multiply}
+}
+
+func (s *semiring[T]) Add() MonoID[T] {
+	return s.add
+}
+
+func (s *semiring[T]) Multiply() BinaryOp[T] {
+	return s.multiply
+}
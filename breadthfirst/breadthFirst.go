@@ -3,6 +3,7 @@
 // This software is released under the MIT License.
 // https://opensource.org/licenses/MIT
 
+// Package breadthfirst searches a graph level by level from one or more sources
 package breadthfirst
 
 import (
@@ -12,7 +13,8 @@ import (
 	"github.com/rossmerr/graphblas/constraints"
 )
 
-// Search a breadth-first search s is the source
+// Search a breadth-first search s is the source, returns the frontier c stopped on
+// or the last frontier reached when the search runs out of vertices
 func Search[T constraints.Number](ctx context.Context, a graphblas.Matrix[T], s int, c func(graphblas.Vector[T]) bool) graphblas.Vector[T] {
 	n := a.Rows()
 	// vertices visited in each level
@@ -31,16 +33,30 @@ func Search[T constraints.Number](ctx context.Context, a graphblas.Matrix[T], s
 	for d < n {
 		d++
 
+		result.Clear()
 		graphblas.MatrixVectorMultiply[T](ctx, a, frontier, visited, result)
 
 		if c(result) {
+			return result
+		}
+
+		if empty[T](result) {
 			break
 		}
 
 		graphblas.ElementWiseVectorAdd[T](ctx, visited, result, nil, visited)
 		frontier = result.Copy().(graphblas.Vector[T])
-		result.Clear()
 	}
 
-	return result
+	return frontier
+}
+
+// empty is true when v holds no non-zero elements
+func empty[T constraints.Number](v graphblas.Vector[T]) bool {
+	for i := 0; i < v.Length(); i++ {
+		if !graphblas.IsZero(v.AtVec(i)) {
+			return false
+		}
+	}
+	return true
 }
@@ -13,17 +13,18 @@ import (
 	"github.com/rossmerr/graphblas/breadthfirst"
 )
 
+var graph = [][]float64{
+	{0, 0, 0, 1, 0, 0, 0},
+	{1, 0, 0, 0, 0, 0, 0},
+	{0, 0, 0, 1, 0, 1, 1},
+	{1, 0, 0, 0, 0, 0, 1},
+	{0, 1, 0, 0, 0, 0, 1},
+	{0, 0, 1, 0, 1, 0, 0},
+	{0, 1, 0, 0, 0, 0, 0},
+}
+
 func TestBreadthFirstSearch(t *testing.T) {
-	array := [][]float64{
-		{0, 0, 0, 1, 0, 0, 0},
-		{1, 0, 0, 0, 0, 0, 0},
-		{0, 0, 0, 1, 0, 1, 1},
-		{1, 0, 0, 0, 0, 0, 1},
-		{0, 1, 0, 0, 0, 0, 1},
-		{0, 0, 1, 0, 1, 0, 0},
-		{0, 1, 0, 0, 0, 0, 0},
-	}
-	g := graphblas.NewDenseMatrixFromArrayN(array)
+	g := graphblas.NewDenseMatrixFromArrayN(graph)
 
 	atx := breadthfirst.Search[float64](context.Background(), g, 3, func(i graphblas.Vector[float64]) bool {
 		return i.AtVec(5) == 1
@@ -37,3 +38,18 @@ func TestBreadthFirstSearch(t *testing.T) {
 		t.Errorf("AtVec(%+v) wanted = %+v got %v", 5, 1, atx.AtVec(5))
 	}
 }
+
+func TestBreadthFirstSearch_Exhausted(t *testing.T) {
+	g := graphblas.NewDenseMatrixFromArrayN(graph)
+
+	atx := breadthfirst.Search[float64](context.Background(), g, 3, func(i graphblas.Vector[float64]) bool {
+		return false
+	})
+
+	want := []float64{0, 0, 0, 0, 1, 0, 1}
+	for i := range want {
+		if atx.AtVec(i) != want[i] {
+			t.Errorf("AtVec(%+v) wanted = %+v got %v", i, want[i], atx.AtVec(i))
+		}
+	}
+}
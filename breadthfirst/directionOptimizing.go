@@ -0,0 +1,137 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package breadthfirst
+
+import (
+	"context"
+	"log"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/constraints"
+)
+
+// DirectionOptimizingOptions the zero value of each field uses its default
+type DirectionOptimizingOptions struct {
+	// Alpha switches from push to pull once the edges leaving the frontier exceed 1 / Alpha of the
+	// edges into the unvisited vertices, defaults to 15
+	Alpha float64
+
+	// Beta switches from pull back to push once the frontier shrinks below n / Beta vertices, defaults to 18
+	Beta float64
+}
+
+func (s DirectionOptimizingOptions) withDefaults() DirectionOptimizingOptions {
+	if s.Alpha == 0 {
+		s.Alpha = 15
+	}
+	if s.Beta == 0 {
+		s.Beta = 18
+	}
+	return s
+}
+
+// DirectionOptimizing a breadth-first search from source over the adjacency matrix a, where a[i, j] is the edge i → j,
+// returning the same levels and parents as Levels and Parents
+//
+// each level multiplies the frontier by aᵀ over min.second, either pushing the sparse frontier along the columns of
+// aᵀ stored by columns, the out-edges of each frontier vertex, or pulling each unvisited vertex from the frontier along
+// the rows of aᵀ stored by rows, its in-edges, the pull skips the visited vertices so it wins once the frontier holds
+// most of the edges left to explore, as in the middle levels of low-diameter graphs
+func DirectionOptimizing[T constraints.Number](ctx context.Context, a graphblas.Matrix[T], source int, options DirectionOptimizingOptions) (levels, parents graphblas.Vector[int], err error) {
+	options = options.withDefaults()
+	n := a.Rows()
+	if source < 0 || source >= n {
+		log.Panicf("Source '%+v' is invalid", source)
+	}
+
+	// in holds the in-edges of each vertex by rows and out the out-edges of each vertex by columns
+	in := graphblas.Structure[T, int](ctx, a, graphblas.StructureOptions{Transpose: true, Pattern: true})
+	out, inDegree, outDegree := columns(in)
+
+	levels = graphblas.NewDenseVectorN[int](n)
+	parents = graphblas.NewDenseVectorN[int](n)
+	for i := 0; i < n; i++ {
+		levels.SetVec(i, -1)
+		parents.SetVec(i, -1)
+	}
+	levels.SetVec(source, 0)
+	parents.SetVec(source, source)
+
+	// visited masks the vertices already reached so neither step computes them again
+	visited := graphblas.NewDenseVectorN[int](n)
+	visited.SetVec(source, 1)
+
+	// unexplored the edges into the unvisited vertices, the work of a pull
+	unexplored := in.Values() - inDegree[source]
+	frontier := []int{source}
+	pull := false
+	minSecond := graphblas.DefaultSemiringMinSecond[int]()
+
+	for level := 1; len(frontier) > 0; level++ {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+
+		// explored the edges leaving the frontier, the work of a push
+		explored := 0
+		for _, u := range frontier {
+			explored += outDegree[u]
+		}
+
+		if pull {
+			pull = float64(len(frontier)) >= float64(n)/options.Beta
+		} else {
+			pull = float64(explored) > float64(unexplored)/options.Alpha
+		}
+
+		// each frontier vertex holds its one-based index so the min.second product picks the lowest parent
+		f := graphblas.NewSparseVector[int](n)
+		for _, u := range frontier {
+			f.SetVec(u, u+1)
+		}
+
+		next := graphblas.NewSparseVector[int](n)
+		if pull {
+			graphblas.MatrixVectorMultiplyWithSemiring[int](ctx, in, f, minSecond, visited, next)
+		} else {
+			graphblas.MatrixVectorMultiplyWithSemiring[int](ctx, out, f, minSecond, visited, next)
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+
+		frontier = frontier[:0]
+		for iterator := next.Enumerate(); iterator.HasNext(); {
+			vertex, _, parent := iterator.Next()
+			visited.SetVec(vertex, 1)
+			levels.SetVec(vertex, level)
+			parents.SetVec(vertex, parent-1)
+			unexplored -= inDegree[vertex]
+			frontier = append(frontier, vertex)
+		}
+	}
+
+	return levels, parents, nil
+}
+
+// columns the matrix g stored by columns along with the number of edges in each row and in each column
+func columns(g *graphblas.CSRMatrix[int]) (*graphblas.CSCMatrix[int], []int, []int) {
+	rowDegree := make([]int, g.Rows())
+	columnDegree := make([]int, g.Columns())
+	rows := make([]int, 0, g.Values())
+	cols := make([]int, 0, g.Values())
+	values := make([]int, 0, g.Values())
+	for iterator := g.Enumerate(); iterator.HasNext(); {
+		r, c, v := iterator.Next()
+		rowDegree[r]++
+		columnDegree[c]++
+		rows = append(rows, r)
+		cols = append(cols, c)
+		values = append(values, v)
+	}
+
+	return graphblas.NewCSCMatrixFromTuples(g.Rows(), g.Columns(), rows, cols, values, nil), rowDegree, columnDegree
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package breadthfirst_test
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/breadthfirst"
+)
+
+func TestDirectionOptimizing(t *testing.T) {
+	random := graphblas.NewCSRMatrix[float64](200, 200)
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		random.Set(rnd.Intn(200), rnd.Intn(200), 1)
+	}
+
+	tests := []struct {
+		name    string
+		g       graphblas.Matrix[float64]
+		source  int
+		options breadthfirst.DirectionOptimizingOptions
+	}{
+		{
+			name:   "Default",
+			g:      graphblas.NewCSRMatrixFromArray(graph),
+			source: 5,
+		},
+		{
+			name:    "Push",
+			g:       graphblas.NewCSRMatrixFromArray(graph),
+			source:  5,
+			options: breadthfirst.DirectionOptimizingOptions{Alpha: 1e-9},
+		},
+		{
+			name:    "Pull",
+			g:       graphblas.NewCSRMatrixFromArray(graph),
+			source:  5,
+			options: breadthfirst.DirectionOptimizingOptions{Alpha: 1e9, Beta: 1e9},
+		},
+		{
+			name:    "Unreached",
+			g:       graphblas.NewCSRMatrixFromArray(graph),
+			source:  3,
+			options: breadthfirst.DirectionOptimizingOptions{Alpha: 1e9, Beta: 1e9},
+		},
+		{
+			name:   "Random",
+			g:      random,
+			source: 0,
+		},
+		{
+			name:    "Random Pull",
+			g:       random,
+			source:  0,
+			options: breadthfirst.DirectionOptimizingOptions{Alpha: 1e9, Beta: 1e9},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			levels, parents, err := breadthfirst.DirectionOptimizing[float64](context.Background(), tt.g, tt.source, tt.options)
+			if err != nil {
+				t.Fatalf("%+v DirectionOptimizing error %+v", tt.name, err)
+			}
+
+			wantLevels, _ := breadthfirst.Levels[float64](context.Background(), tt.g, []int{tt.source})
+			wantParents, _ := breadthfirst.Parents[float64](context.Background(), tt.g, []int{tt.source})
+
+			for i := 0; i < tt.g.Rows(); i++ {
+				if levels.AtVec(i) != wantLevels.At(0, i) {
+					t.Errorf("%+v DirectionOptimizing levels AtVec(%+v) = %+v, want %+v", tt.name, i, levels.AtVec(i), wantLevels.At(0, i))
+				}
+				if parents.AtVec(i) != wantParents.At(0, i) {
+					t.Errorf("%+v DirectionOptimizing parents AtVec(%+v) = %+v, want %+v", tt.name, i, parents.AtVec(i), wantParents.At(0, i))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package breadthfirst
+
+import (
+	"context"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/constraints"
+)
+
+// Levels the number of edges on the shortest path from each source to every vertex of the
+// adjacency matrix a, where a[i, j] is the edge i → j
+//
+// row i of the result is the search from sources[i], the source is at level 0 and unreached vertices are -1
+func Levels[T constraints.Number](ctx context.Context, a graphblas.Matrix[T], sources []int) (graphblas.Matrix[int], error) {
+	levels := graphblas.NewDenseMatrixN[int](len(sources), a.Rows())
+	fill(levels, -1)
+	for i, source := range sources {
+		levels.Set(i, source, 0)
+	}
+
+	err := search(ctx, a, sources, func(source, vertex, level, parent int) {
+		levels.Set(source, vertex, level)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return levels, nil
+}
+
+// Parents the breadth-first tree from each source over the adjacency matrix a, where a[i, j] is the edge i → j,
+// each vertex holds the lowest numbered vertex one level closer to the source
+//
+// row i of the result is the search from sources[i], the source is its own parent and unreached vertices are -1
+func Parents[T constraints.Number](ctx context.Context, a graphblas.Matrix[T], sources []int) (graphblas.Matrix[int], error) {
+	parents := graphblas.NewDenseMatrixN[int](len(sources), a.Rows())
+	fill(parents, -1)
+	for i, source := range sources {
+		parents.Set(i, source, source)
+	}
+
+	err := search(ctx, a, sources, func(source, vertex, level, parent int) {
+		parents.Set(source, vertex, parent)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return parents, nil
+}
+
+// search a multi-source breadth-first search, row i of the frontier is the search from sources[i] and holds
+// the one-based position of each vertex, visit is called with the level and parent of each vertex reached
+func search[T constraints.Number](ctx context.Context, a graphblas.Matrix[T], sources []int, visit func(source, vertex, level, parent int)) error {
+	k := len(sources)
+	n := a.Rows()
+	g := graphblas.Structure[T, int](ctx, a, graphblas.StructureOptions{Pattern: true})
+
+	var frontier graphblas.Matrix[int] = graphblas.NewCSRMatrix[int](k, n)
+	visited := graphblas.NewDenseMatrixN[int](k, n)
+	for i, source := range sources {
+		frontier.Set(i, source, source+1)
+		visited.Set(i, source, 1)
+	}
+
+	for level := 1; ; level++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		// the visited mask skips every vertex already reached
+		next := graphblas.NewCSRMatrix[int](k, n)
+		graphblas.MatrixMatrixMultiplyWithSemiring[int](ctx, frontier, g, graphblas.DefaultSemiringMinFirst[int](), visited, next)
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if next.Values() == 0 {
+			return nil
+		}
+
+		for iterator := next.Map(); iterator.HasNext(); {
+			iterator.Map(func(r, c int, v int) int {
+				visited.Set(r, c, 1)
+				visit(r, c, level, v-1)
+				return c + 1
+			})
+		}
+
+		frontier = next
+	}
+}
+
+// fill sets every element of m to value
+func fill(m graphblas.Matrix[int], value int) {
+	for r := 0; r < m.Rows(); r++ {
+		for c := 0; c < m.Columns(); c++ {
+			m.Set(r, c, value)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package breadthfirst_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/breadthfirst"
+)
+
+func TestLevels(t *testing.T) {
+	tests := []struct {
+		name    string
+		sources []int
+		want    [][]int
+	}{
+		{
+			name:    "Single",
+			sources: []int{3},
+			want:    [][]int{{1, 2, -1, 0, -1, -1, 1}},
+		},
+		{
+			name:    "Multiple",
+			sources: []int{3, 5},
+			want: [][]int{
+				{1, 2, -1, 0, -1, -1, 1},
+				{3, 2, 1, 2, 1, 0, 2},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := graphblas.NewCSRMatrixFromArray(graph)
+			levels, err := breadthfirst.Levels[float64](context.Background(), g, tt.sources)
+			if err != nil {
+				t.Fatalf("%+v Levels error %+v", tt.name, err)
+			}
+
+			for r := range tt.want {
+				for c := range tt.want[r] {
+					if levels.At(r, c) != tt.want[r][c] {
+						t.Errorf("%+v Levels At(%+v, %+v) = %+v, want %+v", tt.name, r, c, levels.At(r, c), tt.want[r][c])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestParents(t *testing.T) {
+	tests := []struct {
+		name    string
+		sources []int
+		want    [][]int
+	}{
+		{
+			name:    "Single",
+			sources: []int{3},
+			want:    [][]int{{3, 6, -1, 3, -1, -1, 3}},
+		},
+		{
+			name:    "Multiple",
+			sources: []int{3, 5},
+			want: [][]int{
+				{3, 6, -1, 3, -1, -1, 3},
+				{1, 4, 5, 2, 5, 5, 2},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := graphblas.NewCSRMatrixFromArray(graph)
+			parents, err := breadthfirst.Parents[float64](context.Background(), g, tt.sources)
+			if err != nil {
+				t.Fatalf("%+v Parents error %+v", tt.name, err)
+			}
+
+			for r := range tt.want {
+				for c := range tt.want[r] {
+					if parents.At(r, c) != tt.want[r][c] {
+						t.Errorf("%+v Parents At(%+v, %+v) = %+v, want %+v", tt.name, r, c, parents.At(r, c), tt.want[r][c])
+					}
+				}
+			}
+		})
+	}
+}
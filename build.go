@@ -0,0 +1,109 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package graphblas
+
+import (
+	"log"
+	"sort"
+
+	"github.com/rossmerr/graphblas/binaryop"
+	"github.com/rossmerr/graphblas/constraints"
+)
+
+// NewCSRMatrixFromTuples returns a CSRMatrix built from the (rows[i], cols[i], values[i]) tuples
+// duplicate tuples are combined with dup, when dup is nil the last value wins
+//
+// build
+func NewCSRMatrixFromTuples[T constraints.Number](r, c int, rows, cols []int, values []T, dup binaryop.BinaryOp[T]) *CSRMatrix[T] {
+	start, index, v := buildCompressed(r, c, rows, cols, values, dup)
+	return &CSRMatrix[T]{
+		r:        r,
+		c:        c,
+		values:   v,
+		cols:     index,
+		rowStart: start,
+	}
+}
+
+// NewCSCMatrixFromTuples returns a CSCMatrix built from the (rows[i], cols[i], values[i]) tuples
+// duplicate tuples are combined with dup, when dup is nil the last value wins
+//
+// build
+func NewCSCMatrixFromTuples[T constraints.Number](r, c int, rows, cols []int, values []T, dup binaryop.BinaryOp[T]) *CSCMatrix[T] {
+	start, index, v := buildCompressed(c, r, cols, rows, values, dup)
+	return &CSCMatrix[T]{
+		r:        r,
+		c:        c,
+		values:   v,
+		rows:     index,
+		colStart: start,
+	}
+}
+
+// buildCompressed groups the tuples by the major index, sorts each group by the minor index
+// and folds duplicates, zero values are dropped as the compressed formats never store them
+func buildCompressed[T constraints.Number](major, minor int, majors, minors []int, values []T, dup binaryop.BinaryOp[T]) ([]int, []int, []T) {
+	if len(majors) != len(minors) || len(majors) != len(values) {
+		log.Panicf("Tuple length mismatch %+v, %+v, %+v", len(majors), len(minors), len(values))
+	}
+
+	start := make([]int, major+1)
+	for i := range majors {
+		if majors[i] < 0 || majors[i] >= major {
+			log.Panicf("Index '%+v' is invalid", majors[i])
+		}
+		if minors[i] < 0 || minors[i] >= minor {
+			log.Panicf("Index '%+v' is invalid", minors[i])
+		}
+		start[majors[i]+1]++
+	}
+
+	for i := 0; i < major; i++ {
+		start[i+1] += start[i]
+	}
+
+	// counting sort on the major index keeps the input order within each group
+	order := make([]int, len(majors))
+	next := make([]int, major)
+	copy(next, start[:major])
+	for i, m := range majors {
+		order[next[m]] = i
+		next[m]++
+	}
+
+	index := make([]int, 0, len(order))
+	v := make([]T, 0, len(order))
+	pointer := make([]int, major+1)
+
+	for m := 0; m < major; m++ {
+		group := order[start[m]:start[m+1]]
+		sort.SliceStable(group, func(a, b int) bool {
+			return minors[group[a]] < minors[group[b]]
+		})
+
+		for i := 0; i < len(group); {
+			n := minors[group[i]]
+			value := values[group[i]]
+			i++
+			for i < len(group) && minors[group[i]] == n {
+				if dup == nil {
+					value = values[group[i]]
+				} else {
+					value = dup.Apply(value, values[group[i]])
+				}
+				i++
+			}
+
+			if !IsZero(value) {
+				index = append(index, n)
+				v = append(v, value)
+			}
+		}
+		pointer[m+1] = len(index)
+	}
+
+	return pointer, index, v
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package graphblas_test
+
+import (
+	"testing"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/binaryop"
+)
+
+func TestMatrix_FromTuples(t *testing.T) {
+	rows := []int{2, 0, 1, 0, 2}
+	cols := []int{1, 2, 0, 2, 2}
+	values := []float64{3, 1, 4, 5, 0}
+
+	tests := []struct {
+		name string
+		s    graphblas.Matrix[float64]
+		want [][]float64
+	}{
+		{
+			name: "CSRMatrix",
+			s:    graphblas.NewCSRMatrixFromTuples(3, 3, rows, cols, values, nil),
+			want: [][]float64{
+				{0, 0, 5},
+				{4, 0, 0},
+				{0, 3, 0},
+			},
+		},
+		{
+			name: "CSCMatrix",
+			s:    graphblas.NewCSCMatrixFromTuples(3, 3, rows, cols, values, nil),
+			want: [][]float64{
+				{0, 0, 5},
+				{4, 0, 0},
+				{0, 3, 0},
+			},
+		},
+		{
+			name: "CSRMatrix Addition",
+			s:    graphblas.NewCSRMatrixFromTuples(3, 3, rows, cols, values, binaryop.Addition[float64]()),
+			want: [][]float64{
+				{0, 0, 6},
+				{4, 0, 0},
+				{0, 3, 0},
+			},
+		},
+		{
+			name: "CSCMatrix Addition",
+			s:    graphblas.NewCSCMatrixFromTuples(3, 3, rows, cols, values, binaryop.Addition[float64]()),
+			want: [][]float64{
+				{0, 0, 6},
+				{4, 0, 0},
+				{0, 3, 0},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := graphblas.NewDenseMatrixFromArrayN(tt.want)
+			if !tt.s.Equal(want) {
+				t.Errorf("%+v FromTuples = %+v, want %+v", tt.name, tt.s, want)
+			}
+
+			if tt.s.Values() != 3 {
+				t.Errorf("%+v Values = %+v, want %+v", tt.name, tt.s.Values(), 3)
+			}
+		})
+	}
+}
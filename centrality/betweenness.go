@@ -1 +1,330 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
 package centrality
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/constraints"
+)
+
+// ErrNegativeWeight the weighted measures use the edge weights as distances so they must be positive
+var ErrNegativeWeight = errors.New("centrality: negative edge weight")
+
+// BetweennessOptions the zero value computes exact, unweighted, unnormalised betweenness
+type BetweennessOptions struct {
+	// Samples the number of random sources, zero uses every vertex for exact betweenness
+	// sampled scores are scaled by n / Samples to estimate the exact score
+	Samples int
+
+	// Seed for picking the sampled sources
+	Seed int64
+
+	// BatchSize the number of sources searched together, defaults to 32
+	BatchSize int
+
+	// Weighted uses the edge weights as distances rather than counting hops
+	Weighted bool
+
+	// Undirected halves the scores as each path is found from both ends
+	Undirected bool
+
+	// Normalized divides the scores by the number of pairs excluding the vertex, (n - 1)(n - 2) ordered pairs or
+	// (n - 1)(n - 2) / 2 unordered pairs when Undirected
+	Normalized bool
+}
+
+func (s BetweennessOptions) withDefaults() BetweennessOptions {
+	if s.BatchSize == 0 {
+		s.BatchSize = 32
+	}
+	return s
+}
+
+// Betweenness centrality of each vertex of the adjacency matrix a, the fraction of shortest paths
+// between all other pairs of vertices that pass through it
+//
+// unweighted graphs use the batched Brandes algorithm, a forward breadth-first search counting the
+// shortest paths with a plus-times mxm masked by the vertices already visited followed by a
+// backward sweep accumulating the dependencies, weighted graphs find the distances of each batch with a
+// min-plus mxm then count the paths and accumulate the dependencies over the edges on a shortest path,
+// a negative weight returns ErrNegativeWeight
+func Betweenness[T constraints.Number](ctx context.Context, a graphblas.Matrix[T], options BetweennessOptions) (graphblas.Vector[float64], error) {
+	options = options.withDefaults()
+	n := a.Rows()
+
+	sources := make([]int, n)
+	for i := range sources {
+		sources[i] = i
+	}
+
+	scale := 1.0
+	if options.Samples > 0 && options.Samples < n {
+		sources = rand.New(rand.NewSource(options.Seed)).Perm(n)[:options.Samples]
+		scale = float64(n) / float64(options.Samples)
+	}
+
+	pairs := float64((n - 1) * (n - 2))
+	if options.Undirected {
+		scale /= 2
+		pairs /= 2
+	}
+
+	if options.Normalized && n > 2 {
+		scale /= pairs
+	}
+
+	centrality := make([]float64, n)
+
+	if options.Weighted {
+		g := graphblas.Structure[T, float64](ctx, a, graphblas.StructureOptions{})
+		if negative(g) {
+			return nil, ErrNegativeWeight
+		}
+
+		for start := 0; start < len(sources); start += options.BatchSize {
+			end := start + options.BatchSize
+			if end > len(sources) {
+				end = len(sources)
+			}
+
+			if err := weightedBatch(ctx, g, sources[start:end], centrality); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		g := graphblas.Structure[T, float64](ctx, a, graphblas.StructureOptions{Pattern: true})
+		gt := graphblas.TransposeToCSR[float64](ctx, g)
+		for start := 0; start < len(sources); start += options.BatchSize {
+			end := start + options.BatchSize
+			if end > len(sources) {
+				end = len(sources)
+			}
+
+			if err := brandesBatch(ctx, g, gt, sources[start:end], centrality); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	result := graphblas.NewDenseVectorN[float64](n)
+	for i, v := range centrality {
+		result.SetVec(i, v*scale)
+	}
+
+	return result, nil
+}
+
+// brandesBatch adds the dependencies of each source onto centrality, row i of each k × n matrix
+// is the search from sources[i]
+func brandesBatch(ctx context.Context, a, at graphblas.Matrix[float64], sources []int, centrality []float64) error {
+	k := len(sources)
+	n := a.Rows()
+
+	// frontier holds the number of shortest paths to the vertices first reached at each level
+	var frontier graphblas.Matrix[float64] = graphblas.NewCSRMatrix[float64](k, n)
+	for i, source := range sources {
+		frontier.Set(i, source, 1)
+	}
+
+	paths := frontier.Copy()
+	levels := []graphblas.Matrix[float64]{frontier}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		// the paths mask skips every vertex already visited
+		next := graphblas.NewCSRMatrix[float64](k, n)
+		graphblas.MatrixMatrixMultiply[float64](ctx, frontier, a, paths, next)
+		if next.Values() == 0 {
+			break
+		}
+
+		for iterator := next.Enumerate(); iterator.HasNext(); {
+			r, c, v := iterator.Next()
+			paths.Set(r, c, v)
+		}
+
+		levels = append(levels, next)
+		frontier = next
+	}
+
+	dependencies := graphblas.NewDenseMatrixN[float64](k, n)
+
+	for d := len(levels) - 1; d > 0; d-- {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		// (1 + δ) / σ on the vertices of level d
+		weight := graphblas.NewCSRMatrix[float64](k, n)
+		for iterator := levels[d].Enumerate(); iterator.HasNext(); {
+			r, c, _ := iterator.Next()
+			weight.Set(r, c, (1+dependencies.At(r, c))/paths.At(r, c))
+		}
+
+		// pulled back along the edges onto the vertices of level d - 1
+		pulled := graphblas.NewCSRMatrix[float64](k, n)
+		graphblas.MatrixMatrixMultiply[float64](ctx, weight, at, graphblas.NewComplement(levels[d-1]), pulled)
+
+		for iterator := pulled.Enumerate(); iterator.HasNext(); {
+			r, c, v := iterator.Next()
+			dependencies.Update(r, c, func(δ float64) float64 {
+				return δ + v*paths.At(r, c)
+			})
+		}
+	}
+
+	for iterator := dependencies.Enumerate(); iterator.HasNext(); {
+		r, c, v := iterator.Next()
+		if c != sources[r] {
+			centrality[c] += v
+		}
+	}
+
+	return nil
+}
+
+// weightedBatch adds the dependencies of each source onto centrality using the edge weights as distances
+//
+// the edges on a shortest path of each search form a DAG, the DAGs of the batch are laid out as the blocks of one
+// k·n × k·n matrix so every search counts its paths with the plus-times mxv σ = e + dagᵀ σ and accumulates its
+// dependencies with δ = σ ∘ dag ((1 + δ) / σ), each repeated until nothing changes
+func weightedBatch(ctx context.Context, a graphblas.Matrix[float64], sources []int, centrality []float64) error {
+	k := len(sources)
+	n := a.Rows()
+
+	distance, err := shortestDistances(ctx, a, sources)
+	if err != nil {
+		return err
+	}
+
+	rows := []int{}
+	cols := []int{}
+	values := []float64{}
+	for iterator := a.Enumerate(); iterator.HasNext(); {
+		u, v, w := iterator.Next()
+		for i := 0; i < k; i++ {
+			if d := distance.At(i, u); !math.IsInf(d, 1) && d+w == distance.At(i, v) {
+				rows = append(rows, i*n+u)
+				cols = append(cols, i*n+v)
+				values = append(values, 1)
+			}
+		}
+	}
+
+	dag := graphblas.NewCSRMatrixFromTuples(k*n, k*n, rows, cols, values, nil)
+	dagt := graphblas.TransposeToCSR[float64](ctx, dag)
+	plusTimes := graphblas.DefaultSemiringPlusTimes[float64]()
+
+	var paths graphblas.Vector[float64] = graphblas.NewDenseVectorN[float64](k * n)
+	for i, source := range sources {
+		paths.SetVec(i*n+source, 1)
+	}
+
+	var next graphblas.Vector[float64] = graphblas.NewDenseVectorN[float64](k * n)
+	for changed := true; changed; {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		graphblas.MatrixVectorMultiplyWithSemiring[float64](ctx, dagt, paths, plusTimes, nil, next)
+		for i, source := range sources {
+			next.SetVec(i*n+source, 1)
+		}
+
+		changed = !next.Equal(paths)
+		paths, next = next, paths
+	}
+
+	dependencies := graphblas.NewDenseVectorN[float64](k * n)
+	weight := graphblas.NewDenseVectorN[float64](k * n)
+	for changed := true; changed; {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		for v := 0; v < k*n; v++ {
+			if σ := paths.AtVec(v); σ > 0 {
+				weight.SetVec(v, (1+dependencies.AtVec(v))/σ)
+			}
+		}
+
+		graphblas.MatrixVectorMultiplyWithSemiring[float64](ctx, dag, weight, plusTimes, nil, next)
+
+		changed = false
+		for v := 0; v < k*n; v++ {
+			if δ := paths.AtVec(v) * next.AtVec(v); δ != dependencies.AtVec(v) {
+				dependencies.SetVec(v, δ)
+				changed = true
+			}
+		}
+	}
+
+	for i, source := range sources {
+		for v := 0; v < n; v++ {
+			if v != source {
+				centrality[v] += dependencies.AtVec(i*n + v)
+			}
+		}
+	}
+
+	return nil
+}
+
+// shortestDistances the distances from each source over the non-negative edge weights of a, row i holds the
+// distances from sources[i] and +Inf where a vertex is not reached, each round relaxes every edge for the whole
+// batch with the min-plus mxm D min.+ a until nothing changes
+func shortestDistances(ctx context.Context, a graphblas.Matrix[float64], sources []int) (*graphblas.DenseMatrixNumber[float64], error) {
+	k := len(sources)
+	n := a.Rows()
+
+	distance := graphblas.NewDenseMatrixN[float64](k, n)
+	for i, source := range sources {
+		for v := 0; v < n; v++ {
+			distance.Set(i, v, math.Inf(1))
+		}
+		distance.Set(i, source, 0)
+	}
+
+	minPlus := graphblas.DefaultSemiringMinPlus[float64]()
+	request := graphblas.NewDenseMatrixN[float64](k, n)
+	for changed := true; changed; {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		graphblas.MatrixMatrixMultiplyWithSemiring[float64](ctx, distance, a, minPlus, nil, request)
+
+		changed = false
+		for i := 0; i < k; i++ {
+			for v := 0; v < n; v++ {
+				if d := request.At(i, v); d < distance.At(i, v) {
+					distance.Set(i, v, d)
+					changed = true
+				}
+			}
+		}
+	}
+
+	return distance, nil
+}
+
+// negative whether any weight of a is negative
+func negative(a graphblas.Matrix[float64]) bool {
+	for iterator := a.Enumerate(); iterator.HasNext(); {
+		if _, _, v := iterator.Next(); v < 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package centrality_test
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/centrality"
+)
+
+func TestBetweenness(t *testing.T) {
+	directed := [][]float64{
+		{0, 1, 4, 0, 0, 0},
+		{0, 0, 1, 2, 0, 0},
+		{0, 0, 0, 1, 3, 0},
+		{1, 0, 0, 0, 1, 2},
+		{0, 0, 0, 0, 0, 1},
+		{0, 1, 0, 0, 0, 0},
+	}
+	path := [][]float64{
+		{0, 1, 0, 0, 0},
+		{1, 0, 1, 0, 0},
+		{0, 1, 0, 1, 0},
+		{0, 0, 1, 0, 1},
+		{0, 0, 0, 1, 0},
+	}
+	star := [][]float64{
+		{0, 1, 1, 1, 1},
+		{1, 0, 0, 0, 0},
+		{1, 0, 0, 0, 0},
+		{1, 0, 0, 0, 0},
+		{1, 0, 0, 0, 0},
+	}
+
+	tests := []struct {
+		name    string
+		s       graphblas.Matrix[float64]
+		options centrality.BetweennessOptions
+		want    []float64
+	}{
+		{
+			name:    "Unweighted",
+			s:       graphblas.NewCSRMatrixFromArray(directed),
+			options: centrality.BetweennessOptions{},
+			want:    []float64{1.833333, 7.833333, 3.166667, 7.833333, 1.166667, 5.166667},
+		},
+		{
+			name:    "Unweighted Batches",
+			s:       graphblas.NewDenseMatrixFromArrayN(directed),
+			options: centrality.BetweennessOptions{BatchSize: 4},
+			want:    []float64{1.833333, 7.833333, 3.166667, 7.833333, 1.166667, 5.166667},
+		},
+		{
+			name:    "Weighted",
+			s:       graphblas.NewCSCMatrixFromArray(directed),
+			options: centrality.BetweennessOptions{Weighted: true},
+			want:    []float64{3, 12, 6, 12, 2, 4},
+		},
+		{
+			name:    "Undirected",
+			s:       graphblas.NewCSRMatrixFromArray(path),
+			options: centrality.BetweennessOptions{Undirected: true},
+			want:    []float64{0, 3, 4, 3, 0},
+		},
+		{
+			name:    "Normalized",
+			s:       graphblas.NewCSRMatrixFromArray(path),
+			options: centrality.BetweennessOptions{Normalized: true},
+			want:    []float64{0, 0.5, 2.0 / 3, 0.5, 0},
+		},
+		{
+			name:    "Undirected Normalized",
+			s:       graphblas.NewCSRMatrixFromArray(path),
+			options: centrality.BetweennessOptions{Undirected: true, Normalized: true},
+			want:    []float64{0, 0.5, 2.0 / 3, 0.5, 0},
+		},
+		{
+			name:    "Undirected Normalized Path",
+			s:       graphblas.NewCSRMatrixFromArray([][]float64{{0, 1, 0}, {1, 0, 1}, {0, 1, 0}}),
+			options: centrality.BetweennessOptions{Undirected: true, Normalized: true},
+			want:    []float64{0, 1, 0},
+		},
+		{
+			name:    "Undirected Normalized Star",
+			s:       graphblas.NewCSRMatrixFromArray(star),
+			options: centrality.BetweennessOptions{Undirected: true, Normalized: true},
+			want:    []float64{1, 0, 0, 0, 0},
+		},
+		{
+			name:    "Sampled",
+			s:       graphblas.NewCSRMatrixFromArray(path),
+			options: centrality.BetweennessOptions{Samples: 5, Seed: 1},
+			want:    []float64{0, 6, 8, 6, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := centrality.Betweenness(context.Background(), tt.s, tt.options)
+			if err != nil {
+				t.Fatalf("%+v Betweenness error %+v", tt.name, err)
+			}
+
+			for i, w := range tt.want {
+				if math.Abs(got.AtVec(i)-w) > 1e-5 {
+					t.Errorf("%+v Betweenness AtVec(%+v) = %+v, want %+v", tt.name, i, got.AtVec(i), w)
+				}
+			}
+		})
+	}
+}
+
+func TestBetweenness_Sampled(t *testing.T) {
+	g := graphblas.NewCSRMatrixFromArray([][]float64{
+		{0, 1, 0, 0, 0},
+		{1, 0, 1, 0, 0},
+		{0, 1, 0, 1, 0},
+		{0, 0, 1, 0, 1},
+		{0, 0, 0, 1, 0},
+	})
+
+	got, err := centrality.Betweenness[float64](context.Background(), g, centrality.BetweennessOptions{Samples: 2, Seed: 7})
+	if err != nil {
+		t.Fatalf("Betweenness error %+v", err)
+	}
+
+	// the end points never lie between a pair
+	if got.AtVec(0) != 0 || got.AtVec(4) != 0 || got.AtVec(2) == 0 {
+		t.Errorf("Betweenness = %+v, want only inner vertices scored", got)
+	}
+}
+
+func TestBetweenness_NegativeWeight(t *testing.T) {
+	g := graphblas.NewCSRMatrixFromArray([][]float64{
+		{0, 1, 0},
+		{0, 0, -1},
+		{0, 0, 0},
+	})
+
+	if _, err := centrality.Betweenness[float64](context.Background(), g, centrality.BetweennessOptions{Weighted: true}); err != centrality.ErrNegativeWeight {
+		t.Errorf("Betweenness error = %+v, want %+v", err, centrality.ErrNegativeWeight)
+	}
+}
@@ -1 +1,173 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
 package centrality
+
+import (
+	"context"
+	"math"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/constraints"
+)
+
+// ClosenessOptions the zero value searches unweighted in batches of 32 sources
+type ClosenessOptions struct {
+	// BatchSize the number of sources searched together, memory grows with BatchSize × n, defaults to 32
+	BatchSize int
+
+	// Weighted uses the edge weights as distances rather than counting hops, a negative weight returns ErrNegativeWeight
+	Weighted bool
+}
+
+func (s ClosenessOptions) withDefaults() ClosenessOptions {
+	if s.BatchSize == 0 {
+		s.BatchSize = 32
+	}
+	return s
+}
+
+// Closeness centrality of each vertex u of the adjacency matrix a from the distances of the paths leaving u,
+// with the Wasserman-Faust normalisation so disconnected graphs compare across components
+//
+//	C(u) = (r / (n - 1)) (r / Σ d(u, v))
+//
+// where r is the number of vertices reachable from u, pass aᵀ to use the paths arriving at u instead
+func Closeness[T constraints.Number](ctx context.Context, a graphblas.Matrix[T], options ClosenessOptions) (graphblas.Vector[float64], error) {
+	n := a.Rows()
+	sum := make([]float64, n)
+	reached := make([]int, n)
+
+	err := distances(ctx, a, options.withDefaults(), func(source, vertex int, d float64) {
+		sum[source] += d
+		reached[source]++
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := graphblas.NewDenseVectorN[float64](n)
+	for u := 0; u < n; u++ {
+		if sum[u] > 0 {
+			r := float64(reached[u])
+			result.SetVec(u, (r/float64(n-1))*(r/sum[u]))
+		}
+	}
+
+	return result, nil
+}
+
+// Harmonic centrality of each vertex u of the adjacency matrix a, unreachable vertices add nothing
+//
+//	H(u) = Σ 1 / d(u, v)
+func Harmonic[T constraints.Number](ctx context.Context, a graphblas.Matrix[T], options ClosenessOptions) (graphblas.Vector[float64], error) {
+	n := a.Rows()
+	result := graphblas.NewDenseVectorN[float64](n)
+
+	err := distances(ctx, a, options.withDefaults(), func(source, vertex int, d float64) {
+		result.Update(source, 0, func(v float64) float64 {
+			return v + 1/d
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// distances calls visit with the distance from each source to every other vertex it reaches,
+// sources are searched in batches so only BatchSize × n distances are held at once
+func distances[T constraints.Number](ctx context.Context, a graphblas.Matrix[T], options ClosenessOptions, visit func(source, vertex int, d float64)) error {
+	n := a.Rows()
+
+	var search func(ctx context.Context, sources []int) error
+	if options.Weighted {
+		g := graphblas.Structure[T, float64](ctx, a, graphblas.StructureOptions{})
+		if negative(g) {
+			return ErrNegativeWeight
+		}
+
+		search = func(ctx context.Context, sources []int) error {
+			return minPlusBatch(ctx, g, sources, visit)
+		}
+	} else {
+		g := graphblas.Structure[T, float64](ctx, a, graphblas.StructureOptions{Pattern: true})
+		search = func(ctx context.Context, sources []int) error {
+			return breadthFirstBatch(ctx, g, sources, visit)
+		}
+	}
+
+	for start := 0; start < n; start += options.BatchSize {
+		end := start + options.BatchSize
+		if end > n {
+			end = n
+		}
+
+		sources := make([]int, end-start)
+		for i := range sources {
+			sources[i] = start + i
+		}
+
+		if err := search(ctx, sources); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// breadthFirstBatch a multi-source breadth-first search, row i of the frontier is the search from sources[i]
+func breadthFirstBatch(ctx context.Context, a graphblas.Matrix[float64], sources []int, visit func(source, vertex int, d float64)) error {
+	k := len(sources)
+	n := a.Rows()
+
+	var frontier graphblas.Matrix[float64] = graphblas.NewCSRMatrix[float64](k, n)
+	for i, source := range sources {
+		frontier.Set(i, source, 1)
+	}
+
+	visited := frontier.Copy()
+
+	for d := 1; ; d++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		// the visited mask skips every vertex already reached
+		next := graphblas.NewCSRMatrix[float64](k, n)
+		graphblas.MatrixMatrixMultiply[float64](ctx, frontier, a, visited, next)
+		if next.Values() == 0 {
+			return nil
+		}
+
+		for iterator := next.Enumerate(); iterator.HasNext(); {
+			r, c, _ := iterator.Next()
+			visited.Set(r, c, 1)
+			visit(sources[r], c, float64(d))
+		}
+
+		frontier = next
+	}
+}
+
+// minPlusBatch the distances from each source by the min-plus mxm D = min(D, D min.+ a) until nothing changes,
+// row i of D holds the distances from sources[i]
+func minPlusBatch(ctx context.Context, a graphblas.Matrix[float64], sources []int, visit func(source, vertex int, d float64)) error {
+	distance, err := shortestDistances(ctx, a, sources)
+	if err != nil {
+		return err
+	}
+
+	for i, source := range sources {
+		for v := 0; v < a.Rows(); v++ {
+			if d := distance.At(i, v); v != source && !math.IsInf(d, 1) {
+				visit(source, v, d)
+			}
+		}
+	}
+
+	return nil
+}
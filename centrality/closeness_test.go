@@ -0,0 +1,143 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package centrality_test
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/centrality"
+)
+
+var closenessGraph = [][]float64{
+	{0, 1, 4, 0, 0, 0},
+	{0, 0, 1, 2, 0, 0},
+	{0, 0, 0, 1, 3, 0},
+	{1, 0, 0, 0, 1, 2},
+	{0, 0, 0, 0, 0, 1},
+	{0, 1, 0, 0, 0, 0},
+}
+
+// two connected vertices and an isolated one
+var closenessDisconnected = [][]float64{
+	{0, 1, 0},
+	{1, 0, 0},
+	{0, 0, 0},
+}
+
+func TestCloseness(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       graphblas.Matrix[float64]
+		options centrality.ClosenessOptions
+		want    []float64
+	}{
+		{
+			name:    "Unweighted",
+			s:       graphblas.NewCSRMatrixFromArray(closenessGraph),
+			options: centrality.ClosenessOptions{},
+			want:    []float64{0.555556, 0.625, 0.555556, 0.714286, 0.384615, 0.454545},
+		},
+		{
+			name:    "Unweighted Batches",
+			s:       graphblas.NewDenseMatrixFromArrayN(closenessGraph),
+			options: centrality.ClosenessOptions{BatchSize: 4},
+			want:    []float64{0.555556, 0.625, 0.555556, 0.714286, 0.384615, 0.454545},
+		},
+		{
+			name:    "Weighted",
+			s:       graphblas.NewCSCMatrixFromArray(closenessGraph),
+			options: centrality.ClosenessOptions{Weighted: true, BatchSize: 4},
+			want:    []float64{0.333333, 0.384615, 0.454545, 0.555556, 0.333333, 0.357143},
+		},
+		{
+			name:    "Disconnected",
+			s:       graphblas.NewCSRMatrixFromArray(closenessDisconnected),
+			options: centrality.ClosenessOptions{},
+			want:    []float64{0.5, 0.5, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := centrality.Closeness(context.Background(), tt.s, tt.options)
+			if err != nil {
+				t.Fatalf("%+v Closeness error %+v", tt.name, err)
+			}
+
+			for i, w := range tt.want {
+				if math.Abs(got.AtVec(i)-w) > 1e-5 {
+					t.Errorf("%+v Closeness AtVec(%+v) = %+v, want %+v", tt.name, i, got.AtVec(i), w)
+				}
+			}
+		})
+	}
+}
+
+func TestHarmonic(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       graphblas.Matrix[float64]
+		options centrality.ClosenessOptions
+		want    []float64
+	}{
+		{
+			name:    "Unweighted",
+			s:       graphblas.NewCSRMatrixFromArray(closenessGraph),
+			options: centrality.ClosenessOptions{BatchSize: 4},
+			want:    []float64{3.333333, 3.5, 3.333333, 4, 2.416667, 2.666667},
+		},
+		{
+			name:    "Weighted",
+			s:       graphblas.NewCSRMatrixFromArray(closenessGraph),
+			options: centrality.ClosenessOptions{Weighted: true},
+			want:    []float64{2.283333, 2.416667, 2.666667, 3.333333, 2.283333, 2.333333},
+		},
+		{
+			name:    "Disconnected",
+			s:       graphblas.NewCSRMatrixFromArray(closenessDisconnected),
+			options: centrality.ClosenessOptions{},
+			want:    []float64{1, 1, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := centrality.Harmonic(context.Background(), tt.s, tt.options)
+			if err != nil {
+				t.Fatalf("%+v Harmonic error %+v", tt.name, err)
+			}
+
+			for i, w := range tt.want {
+				if math.Abs(got.AtVec(i)-w) > 1e-5 {
+					t.Errorf("%+v Harmonic AtVec(%+v) = %+v, want %+v", tt.name, i, got.AtVec(i), w)
+				}
+			}
+		})
+	}
+}
+
+func TestCloseness_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	g := graphblas.NewCSRMatrixFromArray(closenessGraph)
+	if _, err := centrality.Closeness[float64](ctx, g, centrality.ClosenessOptions{}); err == nil {
+		t.Errorf("Closeness error = nil, want %+v", context.Canceled)
+	}
+}
+
+func TestCloseness_NegativeWeight(t *testing.T) {
+	g := graphblas.NewCSRMatrixFromArray([][]float64{
+		{0, 1, 0},
+		{0, 0, -1},
+		{0, 0, 0},
+	})
+
+	if _, err := centrality.Closeness[float64](context.Background(), g, centrality.ClosenessOptions{Weighted: true}); err != centrality.ErrNegativeWeight {
+		t.Errorf("Closeness error = %+v, want %+v", err, centrality.ErrNegativeWeight)
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package centrality
+
+import (
+	"context"
+	"math"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/constraints"
+)
+
+// EigenvectorOptions the zero value of each field uses its default
+type EigenvectorOptions struct {
+	// Tolerance stops once the L1 change in the scores falls below, defaults to 1e-6
+	Tolerance float64
+
+	// MaxIterations defaults to 100
+	MaxIterations int
+}
+
+func (s EigenvectorOptions) withDefaults() EigenvectorOptions {
+	if s.Tolerance == 0 {
+		s.Tolerance = 1e-6
+	}
+	if s.MaxIterations == 0 {
+		s.MaxIterations = 100
+	}
+	return s
+}
+
+// Eigenvector centrality of each vertex of the adjacency matrix a, the principal eigenvector of aᵀ
+// so a vertex scores highly when the vertices with edges into it do, found by power iteration
+//
+//	x = (aᵀ + I) x / ‖(aᵀ + I) x‖
+//
+// the identity shift keeps the iteration from oscillating on bipartite graphs without changing the eigenvector
+func Eigenvector[T constraints.Number](ctx context.Context, a graphblas.Matrix[T], options EigenvectorOptions) (graphblas.Vector[float64], Convergence, error) {
+	options = options.withDefaults()
+	n := a.Rows()
+	convergence := Convergence{}
+
+	if err := ctx.Err(); err != nil {
+		return nil, convergence, err
+	}
+
+	at := graphblas.Structure[T, float64](ctx, a, graphblas.StructureOptions{Transpose: true})
+
+	var x graphblas.Vector[float64] = graphblas.NewDenseVectorN[float64](n)
+	for i := 0; i < n; i++ {
+		x.SetVec(i, 1/float64(n))
+	}
+
+	var next graphblas.Vector[float64] = graphblas.NewDenseVectorN[float64](n)
+
+	for convergence.Iterations < options.MaxIterations {
+		if err := ctx.Err(); err != nil {
+			return x, convergence, err
+		}
+
+		convergence.Iterations++
+
+		graphblas.MatrixVectorMultiply[float64](ctx, at, x, nil, next)
+		for iterator := next.Map(); iterator.HasNext(); {
+			iterator.Map(func(r, c int, v float64) float64 {
+				return v + x.AtVec(r)
+			})
+		}
+
+		norm := euclidean(ctx, next)
+		if err := ctx.Err(); err != nil {
+			return x, convergence, err
+		}
+		if norm == 0 {
+			break
+		}
+		scale(next, 1/norm)
+
+		convergence.Delta = distance(x, next)
+		x, next = next, x
+
+		if convergence.Delta < options.Tolerance {
+			convergence.Converged = true
+			break
+		}
+	}
+
+	return x, convergence, nil
+}
+
+// euclidean the L2 norm of s
+func euclidean(ctx context.Context, s graphblas.Vector[float64]) float64 {
+	squares := s.Copy()
+	for iterator := squares.Map(); iterator.HasNext(); {
+		iterator.Map(func(r, c int, v float64) float64 {
+			return v * v
+		})
+	}
+
+	return math.Sqrt(graphblas.ReduceMatrixToScalar[float64](ctx, squares, nil))
+}
+
+// scale multiplies each element of s by f
+func scale(s graphblas.Vector[float64], f float64) {
+	for iterator := s.Map(); iterator.HasNext(); {
+		iterator.Map(func(r, c int, v float64) float64 {
+			return v * f
+		})
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package centrality_test
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/centrality"
+	"github.com/rossmerr/graphblas/internal/cancel"
+)
+
+func TestEigenvector(t *testing.T) {
+	want := []float64{0.150658, 0.279493, 0.357628, 0.371611, 0.585624, 0.538737}
+
+	tests := []struct {
+		name string
+		s    graphblas.Matrix[float64]
+	}{
+		{
+			name: "DenseMatrix",
+			s:    graphblas.NewDenseMatrixFromArrayN(closenessGraph),
+		},
+		{
+			name: "CSCMatrix",
+			s:    graphblas.NewCSCMatrixFromArray(closenessGraph),
+		},
+		{
+			name: "CSRMatrix",
+			s:    graphblas.NewCSRMatrixFromArray(closenessGraph),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x, convergence, err := centrality.Eigenvector(context.Background(), tt.s, centrality.EigenvectorOptions{Tolerance: 1e-10, MaxIterations: 1000})
+			if err != nil {
+				t.Fatalf("%+v Eigenvector error %+v", tt.name, err)
+			}
+
+			if !convergence.Converged {
+				t.Errorf("%+v Eigenvector did not converge %+v", tt.name, convergence)
+			}
+
+			for i, w := range want {
+				if math.Abs(x.AtVec(i)-w) > 1e-5 {
+					t.Errorf("%+v Eigenvector AtVec(%+v) = %+v, want %+v", tt.name, i, x.AtVec(i), w)
+				}
+			}
+		})
+	}
+}
+
+func TestEigenvector_MaxIterations(t *testing.T) {
+	g := graphblas.NewCSRMatrixFromArray(closenessGraph)
+
+	_, convergence, _ := centrality.Eigenvector[float64](context.Background(), g, centrality.EigenvectorOptions{MaxIterations: 2})
+	if convergence.Iterations != 2 || convergence.Converged {
+		t.Errorf("Eigenvector = %+v, want 2 iterations without converging", convergence)
+	}
+}
+
+func TestEigenvector_Cancel(t *testing.T) {
+	err := cancel.Every(time.Second, func(ctx context.Context) error {
+		_, _, err := centrality.Eigenvector[float64](ctx, graphblas.NewCSRMatrixFromArray(closenessGraph), centrality.EigenvectorOptions{})
+		return err
+	})
+	if err != nil {
+		t.Errorf("Eigenvector %+v", err)
+	}
+}
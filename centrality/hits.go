@@ -0,0 +1,105 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package centrality
+
+import (
+	"context"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/constraints"
+)
+
+// HITSOptions the zero value of each field uses its default
+type HITSOptions struct {
+	// Tolerance stops once the L1 change in the hub scores falls below, defaults to 1e-8
+	Tolerance float64
+
+	// MaxIterations defaults to 100
+	MaxIterations int
+}
+
+func (s HITSOptions) withDefaults() HITSOptions {
+	if s.Tolerance == 0 {
+		s.Tolerance = 1e-8
+	}
+	if s.MaxIterations == 0 {
+		s.MaxIterations = 100
+	}
+	return s
+}
+
+// HITS hub and authority scores of each vertex of the adjacency matrix a, a good hub has edges to
+// good authorities and a good authority has edges from good hubs
+//
+//	authorities = aᵀ hubs
+//	hubs = a authorities
+//
+// each is scaled by its maximum every iteration and the results sum to 1
+func HITS[T constraints.Number](ctx context.Context, a graphblas.Matrix[T], options HITSOptions) (hubs, authorities graphblas.Vector[float64], convergence Convergence, err error) {
+	options = options.withDefaults()
+	n := a.Rows()
+
+	if err := ctx.Err(); err != nil {
+		return nil, nil, convergence, err
+	}
+
+	g := graphblas.Structure[T, float64](ctx, a, graphblas.StructureOptions{})
+	gt := graphblas.TransposeToCSR[float64](ctx, g)
+
+	hubs = graphblas.NewDenseVectorN[float64](n)
+	for i := 0; i < n; i++ {
+		hubs.SetVec(i, 1/float64(n))
+	}
+
+	authorities = graphblas.NewDenseVectorN[float64](n)
+	var next graphblas.Vector[float64] = graphblas.NewDenseVectorN[float64](n)
+
+	for convergence.Iterations < options.MaxIterations {
+		if err := ctx.Err(); err != nil {
+			return hubs, authorities, convergence, err
+		}
+
+		convergence.Iterations++
+
+		graphblas.MatrixVectorMultiply[float64](ctx, gt, hubs, nil, authorities)
+		graphblas.MatrixVectorMultiply[float64](ctx, g, authorities, nil, next)
+
+		max := graphblas.ReduceVectorToScalarWithMonoID[float64](ctx, next, graphblas.DefaultMonoIDMaximum[float64](), nil)
+		if err := ctx.Err(); err != nil {
+			return hubs, authorities, convergence, err
+		}
+		if max == 0 {
+			break
+		}
+		scale(next, 1/max)
+
+		if max := graphblas.ReduceVectorToScalarWithMonoID[float64](ctx, authorities, graphblas.DefaultMonoIDMaximum[float64](), nil); max > 0 {
+			scale(authorities, 1/max)
+		}
+
+		if err := ctx.Err(); err != nil {
+			return hubs, authorities, convergence, err
+		}
+
+		convergence.Delta = distance(hubs, next)
+		hubs, next = next, hubs
+
+		if convergence.Delta < options.Tolerance {
+			convergence.Converged = true
+			break
+		}
+	}
+
+	if sum := graphblas.ReduceVectorToScalar[float64](ctx, hubs, nil); sum > 0 {
+		scale(hubs, 1/sum)
+	}
+
+	if sum := graphblas.ReduceVectorToScalar[float64](ctx, authorities, nil); sum > 0 {
+		scale(authorities, 1/sum)
+	}
+
+	return hubs, authorities, convergence, ctx.Err()
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package centrality_test
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/centrality"
+	"github.com/rossmerr/graphblas/internal/cancel"
+)
+
+func TestHITS(t *testing.T) {
+	wantHubs := []float64{0.677318, 0.211979, 0.056046, 0.01392, 0.001608, 0.039129}
+	wantAuthorities := []float64{0.003205, 0.164961, 0.672615, 0.11052, 0.041918, 0.006781}
+
+	tests := []struct {
+		name string
+		s    graphblas.Matrix[float64]
+	}{
+		{
+			name: "DenseMatrix",
+			s:    graphblas.NewDenseMatrixFromArrayN(closenessGraph),
+		},
+		{
+			name: "CSRMatrix",
+			s:    graphblas.NewCSRMatrixFromArray(closenessGraph),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hubs, authorities, convergence, err := centrality.HITS(context.Background(), tt.s, centrality.HITSOptions{Tolerance: 1e-12, MaxIterations: 1000})
+			if err != nil {
+				t.Fatalf("%+v HITS error %+v", tt.name, err)
+			}
+
+			if !convergence.Converged {
+				t.Errorf("%+v HITS did not converge %+v", tt.name, convergence)
+			}
+
+			for i := range wantHubs {
+				if math.Abs(hubs.AtVec(i)-wantHubs[i]) > 1e-5 {
+					t.Errorf("%+v HITS hubs AtVec(%+v) = %+v, want %+v", tt.name, i, hubs.AtVec(i), wantHubs[i])
+				}
+				if math.Abs(authorities.AtVec(i)-wantAuthorities[i]) > 1e-5 {
+					t.Errorf("%+v HITS authorities AtVec(%+v) = %+v, want %+v", tt.name, i, authorities.AtVec(i), wantAuthorities[i])
+				}
+			}
+		})
+	}
+}
+
+func TestHITS_Cancel(t *testing.T) {
+	err := cancel.Every(time.Second, func(ctx context.Context) error {
+		_, _, _, err := centrality.HITS[float64](ctx, graphblas.NewCSRMatrixFromArray(closenessGraph), centrality.HITSOptions{})
+		return err
+	})
+	if err != nil {
+		t.Errorf("HITS %+v", err)
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package centrality
+
+import (
+	"context"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/constraints"
+)
+
+// KatzOptions the zero value of each field uses its default
+type KatzOptions struct {
+	// Alpha the attenuation of each further edge, must be below 1 / λ of the largest eigenvalue to converge, defaults to 0.1
+	Alpha float64
+
+	// Beta the score every vertex is given before any edges are followed, defaults to 1
+	Beta float64
+
+	// Tolerance stops once the L1 change in the scores falls below, defaults to 1e-6
+	Tolerance float64
+
+	// MaxIterations defaults to 1000
+	MaxIterations int
+
+	// Normalized scales the scores to a unit L2 norm
+	Normalized bool
+}
+
+func (s KatzOptions) withDefaults() KatzOptions {
+	if s.Alpha == 0 {
+		s.Alpha = 0.1
+	}
+	if s.Beta == 0 {
+		s.Beta = 1
+	}
+	if s.Tolerance == 0 {
+		s.Tolerance = 1e-6
+	}
+	if s.MaxIterations == 0 {
+		s.MaxIterations = 1000
+	}
+	return s
+}
+
+// Katz centrality of each vertex of the adjacency matrix a, the walks arriving at a vertex
+// attenuated by alpha for each edge they take
+//
+//	x = α aᵀ x + β
+func Katz[T constraints.Number](ctx context.Context, a graphblas.Matrix[T], options KatzOptions) (graphblas.Vector[float64], Convergence, error) {
+	options = options.withDefaults()
+	n := a.Rows()
+	convergence := Convergence{}
+
+	if err := ctx.Err(); err != nil {
+		return nil, convergence, err
+	}
+
+	at := graphblas.Structure[T, float64](ctx, a, graphblas.StructureOptions{Transpose: true})
+
+	var x graphblas.Vector[float64] = graphblas.NewDenseVectorN[float64](n)
+	var next graphblas.Vector[float64] = graphblas.NewDenseVectorN[float64](n)
+
+	for convergence.Iterations < options.MaxIterations {
+		if err := ctx.Err(); err != nil {
+			return x, convergence, err
+		}
+
+		convergence.Iterations++
+
+		graphblas.MatrixVectorMultiply[float64](ctx, at, x, nil, next)
+		for iterator := next.Map(); iterator.HasNext(); {
+			iterator.Map(func(r, c int, v float64) float64 {
+				return options.Alpha*v + options.Beta
+			})
+		}
+
+		convergence.Delta = distance(x, next)
+		x, next = next, x
+
+		if convergence.Delta < options.Tolerance {
+			convergence.Converged = true
+			break
+		}
+	}
+
+	if options.Normalized {
+		if norm := euclidean(ctx, x); norm > 0 {
+			scale(x, 1/norm)
+		}
+	}
+
+	return x, convergence, ctx.Err()
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package centrality_test
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/centrality"
+	"github.com/rossmerr/graphblas/internal/cancel"
+)
+
+func TestKatz(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       graphblas.Matrix[float64]
+		options centrality.KatzOptions
+		want    []float64
+	}{
+		{
+			name:    "CSRMatrix",
+			s:       graphblas.NewCSRMatrixFromArray(closenessGraph),
+			options: centrality.KatzOptions{Tolerance: 1e-10},
+			want:    []float64{1.140992, 1.258454, 1.582242, 1.409915, 1.615664, 1.443549},
+		},
+		{
+			name:    "Normalized",
+			s:       graphblas.NewDenseMatrixFromArrayN(closenessGraph),
+			options: centrality.KatzOptions{Tolerance: 1e-10, Normalized: true},
+			want:    []float64{0.328404, 0.362213, 0.455406, 0.405807, 0.465026, 0.415487},
+		},
+		{
+			name:    "Edgeless",
+			s:       graphblas.NewCSRMatrix[float64](3, 3),
+			options: centrality.KatzOptions{Alpha: 0.5, Beta: 2},
+			want:    []float64{2, 2, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x, convergence, err := centrality.Katz(context.Background(), tt.s, tt.options)
+			if err != nil {
+				t.Fatalf("%+v Katz error %+v", tt.name, err)
+			}
+
+			if !convergence.Converged {
+				t.Errorf("%+v Katz did not converge %+v", tt.name, convergence)
+			}
+
+			for i, w := range tt.want {
+				if math.Abs(x.AtVec(i)-w) > 1e-5 {
+					t.Errorf("%+v Katz AtVec(%+v) = %+v, want %+v", tt.name, i, x.AtVec(i), w)
+				}
+			}
+		})
+	}
+}
+
+func TestKatz_Cancel(t *testing.T) {
+	ctx, stop := context.WithCancel(context.Background())
+	stop()
+
+	g := graphblas.NewCSRMatrixFromArray(closenessGraph)
+	if _, _, err := centrality.Katz[float64](ctx, g, centrality.KatzOptions{}); err == nil {
+		t.Errorf("Katz error = nil, want %+v", context.Canceled)
+	}
+
+	err := cancel.Every(time.Second, func(ctx context.Context) error {
+		_, _, err := centrality.Katz[float64](ctx, g, centrality.KatzOptions{Normalized: true})
+		return err
+	})
+	if err != nil {
+		t.Errorf("Katz %+v", err)
+	}
+}
@@ -1 +1,134 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+// Package centrality ranks the vertices of a graph by their importance
 package centrality
+
+import (
+	"context"
+	"math"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/constraints"
+)
+
+// Convergence reports how an iterative centrality finished
+type Convergence struct {
+	// Iterations the number of iterations run
+	Iterations int
+
+	// Delta the L1 norm of the change in the last iteration
+	Delta float64
+
+	// Converged the delta fell below the tolerance before the iteration limit
+	Converged bool
+}
+
+// PageRankOptions the zero value of each field uses its default
+type PageRankOptions struct {
+	// Damping the probability of following an edge rather than jumping, defaults to 0.85
+	Damping float64
+
+	// Tolerance stops once the L1 change in the ranks falls below, defaults to 1e-6
+	Tolerance float64
+
+	// MaxIterations defaults to 100
+	MaxIterations int
+}
+
+func (s PageRankOptions) withDefaults() PageRankOptions {
+	if s.Damping == 0 {
+		s.Damping = 0.85
+	}
+	if s.Tolerance == 0 {
+		s.Tolerance = 1e-6
+	}
+	if s.MaxIterations == 0 {
+		s.MaxIterations = 100
+	}
+	return s
+}
+
+// PageRank of each vertex of the adjacency matrix a, where a[i, j] is the weight of the edge i → j
+// the rank of dangling vertices, those without out-edges, is spread evenly over all vertices
+//
+//	r = (1 - d) / n + d (Pᵀ r + Σ r[dangling] / n)
+//
+// where P is a with each row divided by its out-degree, the ranks sum to 1
+func PageRank[T constraints.Number](ctx context.Context, a graphblas.Matrix[T], options PageRankOptions) (graphblas.Vector[float64], Convergence, error) {
+	options = options.withDefaults()
+	n := a.Rows()
+	convergence := Convergence{}
+
+	if err := ctx.Err(); err != nil {
+		return nil, convergence, err
+	}
+
+	// Pᵀ with column j holding the out-edges of vertex j
+	pt := graphblas.Structure[T, float64](ctx, a, graphblas.StructureOptions{Transpose: true})
+
+	outDegree := graphblas.ReduceMatrixToVectorWithMonoID[float64](ctx, pt, graphblas.DefaultMonoIDAddition[float64](), nil)
+	if err := ctx.Err(); err != nil {
+		return nil, convergence, err
+	}
+
+	for iterator := pt.Map(); iterator.HasNext(); {
+		iterator.Map(func(r, c int, v float64) float64 {
+			return v / outDegree.AtVec(c)
+		})
+	}
+
+	var rank graphblas.Vector[float64] = graphblas.NewDenseVectorN[float64](n)
+	for i := 0; i < n; i++ {
+		rank.SetVec(i, 1/float64(n))
+	}
+
+	var next graphblas.Vector[float64] = graphblas.NewDenseVectorN[float64](n)
+
+	for convergence.Iterations < options.MaxIterations {
+		select {
+		case <-ctx.Done():
+			return rank, convergence, ctx.Err()
+		default:
+		}
+
+		convergence.Iterations++
+
+		graphblas.MatrixVectorMultiply[float64](ctx, pt, rank, nil, next)
+
+		// the out-degree masks every vertex with an out-edge leaving the dangling ones
+		dangling := graphblas.ReduceVectorToScalarWithMonoID[float64](ctx, rank, graphblas.DefaultMonoIDAddition[float64](), outDegree)
+		if err := ctx.Err(); err != nil {
+			return rank, convergence, err
+		}
+
+		teleport := (1-options.Damping)/float64(n) + options.Damping*dangling/float64(n)
+
+		for iterator := next.Map(); iterator.HasNext(); {
+			iterator.Map(func(r, c int, v float64) float64 {
+				return options.Damping*v + teleport
+			})
+		}
+
+		convergence.Delta = distance(rank, next)
+		rank, next = next, rank
+
+		if convergence.Delta < options.Tolerance {
+			convergence.Converged = true
+			break
+		}
+	}
+
+	return rank, convergence, nil
+}
+
+// distance the L1 norm of s - m
+func distance(s, m graphblas.Vector[float64]) float64 {
+	sum := 0.0
+	for i := 0; i < s.Length(); i++ {
+		sum += math.Abs(s.AtVec(i) - m.AtVec(i))
+	}
+	return sum
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package centrality_test
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/centrality"
+	"github.com/rossmerr/graphblas/internal/cancel"
+)
+
+func TestPageRank(t *testing.T) {
+	array := [][]float64{
+		{0, 1, 1, 0},
+		{0, 0, 1, 0},
+		{1, 0, 0, 1},
+		{0, 0, 0, 0},
+	}
+	want := []float64{0.233994, 0.186671, 0.345341, 0.233994}
+
+	tests := []struct {
+		name string
+		s    graphblas.Matrix[float64]
+	}{
+		{
+			name: "DenseMatrix",
+			s:    graphblas.NewDenseMatrixFromArrayN(array),
+		},
+		{
+			name: "CSCMatrix",
+			s:    graphblas.NewCSCMatrixFromArray(array),
+		},
+		{
+			name: "CSRMatrix",
+			s:    graphblas.NewCSRMatrixFromArray(array),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rank, convergence, err := centrality.PageRank(context.Background(), tt.s, centrality.PageRankOptions{Tolerance: 1e-9})
+			if err != nil {
+				t.Fatalf("%+v PageRank error %+v", tt.name, err)
+			}
+
+			if !convergence.Converged {
+				t.Errorf("%+v PageRank did not converge %+v", tt.name, convergence)
+			}
+
+			for i, w := range want {
+				if math.Abs(rank.AtVec(i)-w) > 1e-5 {
+					t.Errorf("%+v PageRank AtVec(%+v) = %+v, want %+v", tt.name, i, rank.AtVec(i), w)
+				}
+			}
+		})
+	}
+}
+
+func TestPageRank_MaxIterations(t *testing.T) {
+	g := graphblas.NewCSRMatrixFromArray([][]int{
+		{0, 1, 0},
+		{0, 0, 1},
+		{1, 1, 0},
+	})
+
+	_, convergence, _ := centrality.PageRank[int](context.Background(), g, centrality.PageRankOptions{MaxIterations: 2})
+	if convergence.Iterations != 2 || convergence.Converged {
+		t.Errorf("PageRank = %+v, want 2 iterations without converging", convergence)
+	}
+}
+
+func TestPageRank_Cycle(t *testing.T) {
+	g := graphblas.NewCSRMatrixFromArray([][]int{
+		{0, 1, 0},
+		{0, 0, 1},
+		{1, 0, 0},
+	})
+
+	// a cycle is uniform from the start
+	rank, convergence, _ := centrality.PageRank[int](context.Background(), g, centrality.PageRankOptions{})
+	if !convergence.Converged || math.Abs(rank.AtVec(0)-1.0/3) > 1e-12 {
+		t.Errorf("PageRank = %+v, %+v want uniform", rank, convergence)
+	}
+}
+
+func TestPageRank_Cancel(t *testing.T) {
+	ctx, stop := context.WithCancel(context.Background())
+	stop()
+
+	g := graphblas.NewCSRMatrixFromArray([][]float64{{0, 1}, {1, 0}})
+	if _, _, err := centrality.PageRank[float64](ctx, g, centrality.PageRankOptions{}); err != context.Canceled {
+		t.Errorf("PageRank error = %+v, want %+v", err, context.Canceled)
+	}
+
+	err := cancel.Every(time.Second, func(ctx context.Context) error {
+		_, _, err := centrality.PageRank[float64](ctx, graphblas.NewCSRMatrixFromArray(closenessGraph), centrality.PageRankOptions{})
+		return err
+	})
+	if err != nil {
+		t.Errorf("PageRank %+v", err)
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package clustering
+
+import (
+	"context"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/colouring"
+	"github.com/rossmerr/graphblas/constraints"
+	"github.com/rossmerr/graphblas/internal/partition"
+)
+
+// Propagation the order the labels are updated in
+type Propagation int
+
+const (
+	// Synchronous updates every vertex at once from the labels of the previous iteration, it can oscillate on bipartite parts of a graph
+	Synchronous Propagation = iota
+
+	// SemiSynchronous colours the graph and updates one colour at a time, the vertices of a colour are not neighbours
+	// so each sees the labels its neighbours took earlier in the iteration, which stops the oscillation
+	SemiSynchronous
+)
+
+// LabelPropagationOptions the zero value of each field uses its default
+type LabelPropagationOptions struct {
+	// Propagation defaults to Synchronous
+	Propagation Propagation
+
+	// Seed for the colouring of SemiSynchronous
+	Seed int64
+
+	// MaxIterations defaults to 100
+	MaxIterations int
+}
+
+func (s LabelPropagationOptions) withDefaults() LabelPropagationOptions {
+	if s.MaxIterations == 0 {
+		s.MaxIterations = 100
+	}
+	return s
+}
+
+// LabelPropagation clusters the vertices of the symmetric adjacency matrix a, each vertex starts with its own label and
+// repeatedly takes the label with the most weight among its neighbours, returning the cluster of each vertex numbered
+// from 0 in order of the first vertex in each cluster and the iterations run
+//
+// the votes are the mxm of a with the n × n label assignment matrix, each row of the votes is reduced to its mode,
+// a vertex keeps its label when it ties for the most weight and otherwise ties go to the lowest label
+func LabelPropagation[T constraints.Number](ctx context.Context, a graphblas.Matrix[T], options LabelPropagationOptions) (graphblas.Vector[int], int, error) {
+	options = options.withDefaults()
+	g := graphblas.Structure[T, float64](ctx, a, graphblas.StructureOptions{RemoveLoops: true})
+	n := g.Rows()
+
+	labels := make([]int, n)
+	vertices := make([]int, n)
+	ones := make([]float64, n)
+	for i := range labels {
+		labels[i] = i
+		vertices[i] = i
+		ones[i] = 1
+	}
+
+	// the vertices updated together, with the mask skipping the rest
+	classes := [][]bool{nil}
+	if options.Propagation == SemiSynchronous {
+		colours, count, err := colouring.JonesPlassmann[float64](ctx, g, colouring.Options{Seed: options.Seed})
+		if err != nil {
+			return nil, 0, err
+		}
+
+		classes = make([][]bool, count)
+		for c := range classes {
+			classes[c] = make([]bool, n)
+		}
+		for v := 0; v < n; v++ {
+			classes[colours.AtVec(v)][v] = true
+		}
+	}
+
+	iterations := 0
+	for iterations < options.MaxIterations {
+		if err := ctx.Err(); err != nil {
+			return nil, iterations, err
+		}
+
+		iterations++
+
+		changed := false
+		for _, class := range classes {
+			var mask graphblas.Mask
+			if class != nil {
+				mask = &rowMask{in: class, columns: n}
+			}
+
+			assignment := graphblas.NewCSRMatrixFromTuples(n, n, vertices, labels, ones, nil)
+			votes := graphblas.NewCSRMatrix[float64](n, n)
+			graphblas.MatrixMatrixMultiply[float64](ctx, g, assignment, mask, votes)
+
+			if err := ctx.Err(); err != nil {
+				return nil, iterations, err
+			}
+
+			next := mode(votes, labels)
+			for i, label := range next {
+				if label != labels[i] {
+					changed = true
+				}
+			}
+			labels = next
+		}
+
+		if !changed {
+			break
+		}
+	}
+
+	return partition.Relabel(labels), iterations, nil
+}
+
+// mode the label with the most votes in each row, keeping the current label on a tie and otherwise taking
+// the lowest, rows without votes keep their label
+func mode(votes *graphblas.CSRMatrix[float64], labels []int) []int {
+	n := len(labels)
+	next := make([]int, n)
+	copy(next, labels)
+	best := make([]float64, n)
+	own := make([]float64, n)
+
+	// the columns of each row are enumerated in order so the first maximum is the lowest label
+	for iterator := votes.Enumerate(); iterator.HasNext(); {
+		r, c, v := iterator.Next()
+		if c == labels[r] {
+			own[r] = v
+		}
+		if v > best[r] {
+			best[r] = v
+			next[r] = c
+		}
+	}
+
+	for r := range next {
+		if best[r] > 0 && own[r] == best[r] {
+			next[r] = labels[r]
+		}
+	}
+
+	return next
+}
+
+// rowMask is a mask excluding every row not in the set
+type rowMask struct {
+	in      []bool
+	columns int
+}
+
+// Columns the number of columns of the mask
+func (s *rowMask) Columns() int {
+	return s.columns
+}
+
+// Rows the number of rows of the mask
+func (s *rowMask) Rows() int {
+	return len(s.in)
+}
+
+// Element of the mask is true for the rows not in the set
+func (s *rowMask) Element(r, c int) bool {
+	return !s.in[r]
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package clustering_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/clustering"
+)
+
+func TestLabelPropagation(t *testing.T) {
+	// a single edge, synchronous updates swap the two labels forever
+	pair := [][]float64{
+		{0, 1},
+		{1, 0},
+	}
+
+	tests := []struct {
+		name       string
+		s          graphblas.Matrix[float64]
+		options    clustering.LabelPropagationOptions
+		want       []int
+		iterations int
+	}{
+		{
+			name:       "Synchronous",
+			s:          graphblas.NewCSRMatrixFromArray(cliques),
+			want:       []int{0, 0, 0, 0, 1, 1, 1, 1},
+			iterations: 3,
+		},
+		{
+			name:    "SemiSynchronous",
+			s:       graphblas.NewCSRMatrixFromArray(cliques),
+			options: clustering.LabelPropagationOptions{Propagation: clustering.SemiSynchronous},
+			want:    []int{0, 0, 0, 0, 1, 1, 1, 1},
+		},
+		{
+			name:       "Oscillation",
+			s:          graphblas.NewCSRMatrixFromArray(pair),
+			options:    clustering.LabelPropagationOptions{MaxIterations: 5},
+			want:       []int{0, 1},
+			iterations: 5,
+		},
+		{
+			name:    "SemiSynchronousPair",
+			s:       graphblas.NewCSRMatrixFromArray(pair),
+			options: clustering.LabelPropagationOptions{Propagation: clustering.SemiSynchronous, MaxIterations: 5},
+			want:    []int{0, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, iterations, err := clustering.LabelPropagation(context.Background(), tt.s, tt.options)
+			if err != nil {
+				t.Fatalf("%+v LabelPropagation error %+v", tt.name, err)
+			}
+
+			if tt.iterations != 0 && iterations != tt.iterations {
+				t.Errorf("%+v LabelPropagation iterations = %+v, want %+v", tt.name, iterations, tt.iterations)
+			}
+
+			for i, w := range tt.want {
+				if got.AtVec(i) != w {
+					t.Errorf("%+v LabelPropagation AtVec(%+v) = %+v, want %+v", tt.name, i, got.AtVec(i), w)
+				}
+			}
+		})
+	}
+}
@@ -1 +1,115 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+// Package clustering groups the vertices of a graph and measures how tightly they cluster
 package clustering
+
+import (
+	"context"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/constraints"
+)
+
+// LocalOptions the zero value expects a symmetric adjacency matrix
+type LocalOptions struct {
+	// Symmetrize treats a directed graph as undirected by adding the reverse of each edge
+	Symmetrize bool
+}
+
+// LocalClustering coefficient of each vertex of the undirected adjacency matrix a, the fraction of
+// pairs of its neighbours that are themselves connected, vertices with fewer than two neighbours score 0
+//
+//	C(i) = 2 t(i) / (d(i) (d(i) - 1))
+//
+// where t(i) is the number of triangles through i and d(i) its degree, self-loops and weights are ignored
+func LocalClustering[T constraints.Number](ctx context.Context, a graphblas.Matrix[T], options LocalOptions) (graphblas.Vector[float64], error) {
+	closed, degree, err := triangles(ctx, a, options)
+	if err != nil {
+		return nil, err
+	}
+
+	n := a.Rows()
+	result := graphblas.NewDenseVectorN[float64](n)
+	for i := 0; i < n; i++ {
+		if d := degree.AtVec(i); d > 1 {
+			result.SetVec(i, closed.AtVec(i)/(d*(d-1)))
+		}
+	}
+
+	return result, nil
+}
+
+// AverageClustering the mean of the local clustering coefficients of a
+func AverageClustering[T constraints.Number](ctx context.Context, a graphblas.Matrix[T], options LocalOptions) (float64, error) {
+	local, err := LocalClustering(ctx, a, options)
+	if err != nil {
+		return 0, err
+	}
+
+	if local.Length() == 0 {
+		return 0, nil
+	}
+
+	sum := graphblas.ReduceVectorToScalar[float64](ctx, local, nil)
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	return sum / float64(local.Length()), nil
+}
+
+// Transitivity the global clustering coefficient of a, the fraction of connected triples that close into a triangle
+//
+//	3 × triangles / triples
+func Transitivity[T constraints.Number](ctx context.Context, a graphblas.Matrix[T], options LocalOptions) (float64, error) {
+	closed, degree, err := triangles(ctx, a, options)
+	if err != nil {
+		return 0, err
+	}
+
+	triples := 0.0
+	for i := 0; i < degree.Length(); i++ {
+		d := degree.AtVec(i)
+		triples += d * (d - 1)
+	}
+
+	if triples == 0 {
+		return 0, nil
+	}
+
+	sum := graphblas.ReduceVectorToScalar[float64](ctx, closed, nil)
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	return sum / triples, nil
+}
+
+// triangles returns twice the number of triangles through each vertex and the degree of each vertex
+//
+// the masked product (A·A) ⊙ A counts for each edge i - j the common neighbours of i and j,
+// its row sums count each triangle through i twice
+func triangles[T constraints.Number](ctx context.Context, a graphblas.Matrix[T], options LocalOptions) (closed, degree graphblas.Vector[float64], err error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	s := graphblas.Structure[T, float64](ctx, a, graphblas.StructureOptions{Symmetrize: options.Symmetrize, RemoveLoops: true, Pattern: true})
+
+	// the complement of s skips every pair without an edge
+	wedges := graphblas.NewCSRMatrix[float64](s.Rows(), s.Columns())
+	graphblas.MatrixMatrixMultiply[float64](ctx, s, s, graphblas.NewComplement(s), wedges)
+
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	// s and wedges are symmetric so reducing the columns reduces the rows
+	closed = graphblas.ReduceMatrixToVectorWithMonoID[float64](ctx, wedges, graphblas.DefaultMonoIDAddition[float64](), nil)
+	degree = graphblas.ReduceMatrixToVectorWithMonoID[float64](ctx, s, graphblas.DefaultMonoIDAddition[float64](), nil)
+
+	return closed, degree, ctx.Err()
+}
@@ -0,0 +1,167 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package clustering_test
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/clustering"
+	"github.com/rossmerr/graphblas/internal/cancel"
+)
+
+// two triangles 0-1-2 and 2-3-4 sharing vertex 2 with a pendant vertex 5 off 4
+var undirected = [][]float64{
+	{0, 1, 1, 0, 0, 0},
+	{1, 0, 1, 0, 0, 0},
+	{1, 1, 0, 1, 1, 0},
+	{0, 0, 1, 0, 1, 0},
+	{0, 0, 1, 1, 0, 1},
+	{0, 0, 0, 0, 1, 0},
+}
+
+// the same graph with each edge in one direction only and a self-loop on 3
+var directed = [][]float64{
+	{0, 1, 1, 0, 0, 0},
+	{0, 0, 1, 0, 0, 0},
+	{0, 0, 0, 1, 1, 0},
+	{0, 0, 0, 1, 1, 0},
+	{0, 0, 0, 0, 0, 1},
+	{0, 0, 0, 0, 0, 0},
+}
+
+func TestLocalClustering(t *testing.T) {
+	want := []float64{1, 1, 1.0 / 3, 1, 1.0 / 3, 0}
+
+	tests := []struct {
+		name    string
+		s       graphblas.Matrix[float64]
+		options clustering.LocalOptions
+	}{
+		{
+			name: "DenseMatrix",
+			s:    graphblas.NewDenseMatrixFromArrayN(undirected),
+		},
+		{
+			name: "CSRMatrix",
+			s:    graphblas.NewCSRMatrixFromArray(undirected),
+		},
+		{
+			name:    "Symmetrize",
+			s:       graphblas.NewCSCMatrixFromArray(directed),
+			options: clustering.LocalOptions{Symmetrize: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := clustering.LocalClustering(context.Background(), tt.s, tt.options)
+			if err != nil {
+				t.Fatalf("%+v LocalClustering error %+v", tt.name, err)
+			}
+
+			for i, w := range want {
+				if math.Abs(got.AtVec(i)-w) > 1e-9 {
+					t.Errorf("%+v LocalClustering AtVec(%+v) = %+v, want %+v", tt.name, i, got.AtVec(i), w)
+				}
+			}
+		})
+	}
+}
+
+func TestAverageClustering(t *testing.T) {
+	g := graphblas.NewCSRMatrixFromArray(undirected)
+
+	got, err := clustering.AverageClustering[float64](context.Background(), g, clustering.LocalOptions{})
+	if err != nil {
+		t.Fatalf("AverageClustering error %+v", err)
+	}
+
+	if want := 11.0 / 18; math.Abs(got-want) > 1e-9 {
+		t.Errorf("AverageClustering = %+v, want %+v", got, want)
+	}
+}
+
+func TestTransitivity(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       graphblas.Matrix[float64]
+		options clustering.LocalOptions
+		want    float64
+	}{
+		{
+			name: "Undirected",
+			s:    graphblas.NewCSRMatrixFromArray(undirected),
+			want: 0.5,
+		},
+		{
+			name:    "Symmetrize",
+			s:       graphblas.NewCSRMatrixFromArray(directed),
+			options: clustering.LocalOptions{Symmetrize: true},
+			want:    0.5,
+		},
+		{
+			name: "Path",
+			s: graphblas.NewCSRMatrixFromArray([][]float64{
+				{0, 1, 0},
+				{1, 0, 1},
+				{0, 1, 0},
+			}),
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := clustering.Transitivity(context.Background(), tt.s, tt.options)
+			if err != nil {
+				t.Fatalf("%+v Transitivity error %+v", tt.name, err)
+			}
+
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("%+v Transitivity = %+v, want %+v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocalClustering_Cancel(t *testing.T) {
+	a := graphblas.NewCSRMatrixFromArray(undirected)
+	tests := []struct {
+		name string
+		f    func(ctx context.Context) error
+	}{
+		{
+			name: "LocalClustering",
+			f: func(ctx context.Context) error {
+				_, err := clustering.LocalClustering[float64](ctx, a, clustering.LocalOptions{})
+				return err
+			},
+		},
+		{
+			name: "AverageClustering",
+			f: func(ctx context.Context) error {
+				_, err := clustering.AverageClustering[float64](ctx, a, clustering.LocalOptions{})
+				return err
+			},
+		},
+		{
+			name: "Transitivity",
+			f: func(ctx context.Context) error {
+				_, err := clustering.Transitivity[float64](ctx, a, clustering.LocalOptions{})
+				return err
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := cancel.Every(time.Second, tt.f); err != nil {
+				t.Errorf("%+v %+v", tt.name, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package clustering
+
+import (
+	"context"
+	"sort"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/constraints"
+	"github.com/rossmerr/graphblas/internal/partition"
+)
+
+// LouvainOptions the zero value of each field uses its default
+type LouvainOptions struct {
+	// Resolution scales the expected weight inside each community, higher values give smaller communities, defaults to 1
+	Resolution float64
+
+	// MaxIterations the passes over the vertices of each level, defaults to 100
+	MaxIterations int
+}
+
+func (s LouvainOptions) withDefaults() LouvainOptions {
+	if s.Resolution == 0 {
+		s.Resolution = 1
+	}
+	if s.MaxIterations == 0 {
+		s.MaxIterations = 100
+	}
+	return s
+}
+
+// Louvain clusters the vertices of the weighted symmetric adjacency matrix a by greedily raising the modularity,
+// returning the community of each vertex and the communities found at each level, all numbered from 0 in
+// order of the first vertex in each community, the last level is the result
+//
+// each level moves every vertex in turn to the neighbouring community with the largest gain in modularity until
+// none move, then contracts each community into a single vertex of the next level with Cᵀ·A·C, where C is
+// the assignment matrix, stopping once a level moves no vertex
+func Louvain[T constraints.Number](ctx context.Context, a graphblas.Matrix[T], options LouvainOptions) (graphblas.Vector[int], []graphblas.Vector[int], error) {
+	options = options.withDefaults()
+	g := graphblas.Structure[T, float64](ctx, a, graphblas.StructureOptions{})
+	n := g.Rows()
+
+	// the vertex of the current level each vertex of a has been contracted into
+	membership := make([]int, n)
+	for v := range membership {
+		membership[v] = v
+	}
+
+	hierarchy := []graphblas.Vector[int]{}
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+
+		community, moved, err := localMoving(ctx, g, options)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !moved {
+			break
+		}
+
+		// number the communities of the level from 0
+		ids := map[int]int{}
+		for v, c := range community {
+			if _, ok := ids[c]; !ok {
+				ids[c] = len(ids)
+			}
+			community[v] = ids[c]
+		}
+
+		for v := range membership {
+			membership[v] = community[membership[v]]
+		}
+		hierarchy = append(hierarchy, partition.Relabel(membership))
+
+		g = contract(ctx, g, community, len(ids))
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if len(hierarchy) == 0 {
+		return partition.Relabel(membership), hierarchy, nil
+	}
+
+	return hierarchy[len(hierarchy)-1], hierarchy, nil
+}
+
+// localMoving moves each vertex of g to the neighbouring community with the largest gain in modularity until no vertex
+// moves, a vertex stays on a tie and otherwise ties go to the lowest community, returns the community of each vertex
+// and whether any moved, or the error of ctx once it is done
+func localMoving(ctx context.Context, g *graphblas.CSRMatrix[float64], options LouvainOptions) ([]int, bool, error) {
+	n := g.Rows()
+	community := make([]int, n)
+	for v := range community {
+		community[v] = v
+	}
+
+	neighbours := make([][]int, n)
+	weights := make([][]float64, n)
+	degree := make([]float64, n)
+	m2 := 0.0
+	for iterator := g.Enumerate(); iterator.HasNext(); {
+		r, c, v := iterator.Next()
+		degree[r] += v
+		m2 += v
+		if r != c {
+			neighbours[r] = append(neighbours[r], c)
+			weights[r] = append(weights[r], v)
+		}
+	}
+
+	if m2 == 0 {
+		return community, false, nil
+	}
+
+	// total the sum of the degrees in each community
+	total := make([]float64, n)
+	copy(total, degree)
+
+	// link the weight from the vertex into each neighbouring community, seen marks the communities in touched as
+	// the weights may be negative and cancel to zero
+	link := make([]float64, n)
+	seen := make([]bool, n)
+	touched := []int{}
+
+	moved := false
+	for pass := 0; pass < options.MaxIterations; pass++ {
+		if err := ctx.Err(); err != nil {
+			return nil, false, err
+		}
+
+		changed := false
+		for v := 0; v < n; v++ {
+			own := community[v]
+			touched = touched[:0]
+			for i, u := range neighbours[v] {
+				c := community[u]
+				if !seen[c] {
+					seen[c] = true
+					touched = append(touched, c)
+				}
+				link[c] += weights[v][i]
+			}
+			sort.Ints(touched)
+
+			total[own] -= degree[v]
+			best := own
+			gain := link[own] - options.Resolution*total[own]*degree[v]/m2
+			for _, c := range touched {
+				if delta := link[c] - options.Resolution*total[c]*degree[v]/m2; delta > gain {
+					best, gain = c, delta
+				}
+			}
+			total[best] += degree[v]
+
+			for _, c := range touched {
+				link[c] = 0
+				seen[c] = false
+			}
+
+			if best != own {
+				community[v] = best
+				changed = true
+			}
+		}
+
+		if !changed {
+			break
+		}
+		moved = true
+	}
+
+	return community, moved, nil
+}
+
+// contract each of the k communities of g into a single vertex, Cᵀ·A·C sums the weights between the communities
+// with the weight inside each on its diagonal
+func contract(ctx context.Context, g *graphblas.CSRMatrix[float64], community []int, k int) *graphblas.CSRMatrix[float64] {
+	n := g.Rows()
+	vertices := make([]int, n)
+	ones := make([]float64, n)
+	for v := range vertices {
+		vertices[v] = v
+		ones[v] = 1
+	}
+
+	c := graphblas.NewCSRMatrixFromTuples(n, k, vertices, community, ones, nil)
+	ct := graphblas.NewCSRMatrixFromTuples(k, n, community, vertices, ones, nil)
+
+	ac := graphblas.NewCSRMatrix[float64](n, k)
+	graphblas.MatrixMatrixMultiply[float64](ctx, g, c, nil, ac)
+
+	contracted := graphblas.NewCSRMatrix[float64](k, k)
+	graphblas.MatrixMatrixMultiply[float64](ctx, ct, ac, nil, contracted)
+
+	return contracted
+}
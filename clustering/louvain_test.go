@@ -0,0 +1,125 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package clustering_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/clustering"
+	"github.com/rossmerr/graphblas/internal/cancel"
+)
+
+// ring of k cliques of the given size, each joined to the next by a single edge
+func ring(k, size int) (graphblas.Matrix[float64], []int) {
+	n := k * size
+	g := graphblas.NewCSRMatrix[float64](n, n)
+	clique := make([]int, n)
+	for q := 0; q < k; q++ {
+		for i := size * q; i < size*(q+1); i++ {
+			clique[i] = q
+			for j := size * q; j < size*(q+1); j++ {
+				if i != j {
+					g.Set(i, j, 1)
+				}
+			}
+		}
+		u, v := size*(q+1)-1, (size*(q+1))%n
+		g.Set(u, v, 1)
+		g.Set(v, u, 1)
+	}
+	return g, clique
+}
+
+func TestLouvain(t *testing.T) {
+	cliques5, ring5 := ring(6, 5)
+
+	// twelve triangles are past the resolution limit so the second level pairs them
+	triangles, ring3 := ring(12, 3)
+	pairs := make([]int, len(ring3))
+	for i, c := range ring3 {
+		pairs[i] = c / 2
+	}
+
+	// an edge and a negative edge from vertex 2 into the second clique cancel out
+	negative := graphblas.NewCSRMatrixFromArray(cliques)
+	negative.Set(2, 5, 1)
+	negative.Set(5, 2, 1)
+	negative.Set(2, 6, -1)
+	negative.Set(6, 2, -1)
+
+	tests := []struct {
+		name   string
+		s      graphblas.Matrix[float64]
+		want   []int
+		first  []int
+		levels int
+	}{
+		{
+			name:   "Cliques",
+			s:      graphblas.NewCSRMatrixFromArray(cliques),
+			want:   []int{0, 0, 0, 0, 1, 1, 1, 1},
+			levels: 1,
+		},
+		{
+			name:   "Negative",
+			s:      negative,
+			want:   []int{0, 0, 0, 0, 1, 1, 1, 1},
+			levels: 1,
+		},
+		{
+			name:   "Ring",
+			s:      cliques5,
+			want:   ring5,
+			levels: 1,
+		},
+		{
+			name:   "Hierarchy",
+			s:      triangles,
+			want:   pairs,
+			first:  ring3,
+			levels: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, hierarchy, err := clustering.Louvain(context.Background(), tt.s, clustering.LouvainOptions{})
+			if err != nil {
+				t.Fatalf("%+v Louvain error %+v", tt.name, err)
+			}
+
+			if len(hierarchy) != tt.levels {
+				t.Errorf("%+v Louvain levels = %+v, want %+v", tt.name, len(hierarchy), tt.levels)
+			}
+
+			for i, w := range tt.want {
+				if got.AtVec(i) != w {
+					t.Errorf("%+v Louvain AtVec(%+v) = %+v, want %+v", tt.name, i, got.AtVec(i), w)
+				}
+			}
+
+			for i, w := range tt.first {
+				if hierarchy[0].AtVec(i) != w {
+					t.Errorf("%+v Louvain level 0 AtVec(%+v) = %+v, want %+v", tt.name, i, hierarchy[0].AtVec(i), w)
+				}
+			}
+		})
+	}
+}
+
+func TestLouvain_Cancel(t *testing.T) {
+	triangles, _ := ring(12, 3)
+
+	err := cancel.Every(time.Second, func(ctx context.Context) error {
+		_, _, err := clustering.Louvain(ctx, triangles, clustering.LouvainOptions{})
+		return err
+	})
+	if err != nil {
+		t.Errorf("Louvain %+v", err)
+	}
+}
@@ -1 +1,219 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
 package clustering
+
+import (
+	"context"
+	"math"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/constraints"
+	"github.com/rossmerr/graphblas/internal/partition"
+	"github.com/rossmerr/graphblas/unaryop"
+)
+
+// MarkovOptions the zero value of each field uses its default
+type MarkovOptions struct {
+	// Expansion the power the flow matrix is raised to each iteration, defaults to 2
+	Expansion int
+
+	// Inflation the power each element is raised to each iteration, higher values give smaller clusters, defaults to 2
+	Inflation float64
+
+	// Prune elements of the normalised flow below are dropped to keep the matrix sparse, defaults to 1e-4
+	Prune float64
+
+	// Tolerance stops once no element of the flow changes by more, defaults to 1e-6
+	Tolerance float64
+
+	// MaxIterations defaults to 100
+	MaxIterations int
+}
+
+func (s MarkovOptions) withDefaults() MarkovOptions {
+	if s.Expansion == 0 {
+		s.Expansion = 2
+	}
+	if s.Inflation == 0 {
+		s.Inflation = 2
+	}
+	if s.Prune == 0 {
+		s.Prune = 1e-4
+	}
+	if s.Tolerance == 0 {
+		s.Tolerance = 1e-6
+	}
+	if s.MaxIterations == 0 {
+		s.MaxIterations = 100
+	}
+	return s
+}
+
+// Markov clusters the vertices of the symmetric adjacency matrix a with the Markov Cluster algorithm,
+// returning the cluster of each vertex numbered from 0 in order of the first vertex in each cluster
+//
+// self-loops are added and the columns normalised into a flow, which is then repeatedly expanded by mxm,
+// inflated by raising each element to a power with Apply, pruned and renormalised until it stops changing,
+// the rows left with flow are the attractors and each vertex joins the attractor with the most flow into it
+func Markov[T constraints.Number](ctx context.Context, a graphblas.Matrix[T], options MarkovOptions) (graphblas.Vector[int], error) {
+	options = options.withDefaults()
+	n := a.Rows()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	rows := []int{}
+	cols := []int{}
+	values := []float64{}
+	for iterator := a.Enumerate(); iterator.HasNext(); {
+		r, c, v := iterator.Next()
+		if !graphblas.IsZero(v) && r != c {
+			rows = append(rows, r)
+			cols = append(cols, c)
+			values = append(values, float64(v))
+		}
+	}
+	for i := 0; i < n; i++ {
+		rows = append(rows, i)
+		cols = append(cols, i)
+		values = append(values, 1)
+	}
+
+	flow := normalize(graphblas.NewCSRMatrixFromTuples(n, n, rows, cols, values, nil), 0)
+
+	inflate := unaryop.NewUnaryOp(func(v float64) float64 {
+		return math.Pow(v, options.Inflation)
+	})
+
+	for iteration := 0; iteration < options.MaxIterations; iteration++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		// inflation works in place so the flow is copied when it is not expanded
+		expanded := flow
+		if options.Expansion == 1 {
+			expanded = flow.Copy().(*graphblas.CSRMatrix[float64])
+		}
+
+		for e := 1; e < options.Expansion; e++ {
+			next := graphblas.NewCSRMatrix[float64](n, n)
+			graphblas.MatrixMatrixMultiply[float64](ctx, expanded, flow, nil, next)
+			expanded = next
+		}
+
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		graphblas.Apply[float64](ctx, expanded, nil, inflate, expanded)
+		next := normalize(expanded, options.Prune)
+
+		delta := change(flow, next)
+		flow = next
+
+		if delta < options.Tolerance {
+			break
+		}
+	}
+
+	return attractors(flow), nil
+}
+
+// normalize returns s with each column summing to 1, elements that fall below prune are dropped
+// and the column normalised again
+func normalize(s *graphblas.CSRMatrix[float64], prune float64) *graphblas.CSRMatrix[float64] {
+	sums := make([]float64, s.Columns())
+	for iterator := s.Enumerate(); iterator.HasNext(); {
+		_, c, v := iterator.Next()
+		sums[c] += v
+	}
+
+	rows := []int{}
+	cols := []int{}
+	values := []float64{}
+	kept := make([]float64, s.Columns())
+	for iterator := s.Enumerate(); iterator.HasNext(); {
+		r, c, v := iterator.Next()
+		if v /= sums[c]; v >= prune && v > 0 {
+			rows = append(rows, r)
+			cols = append(cols, c)
+			values = append(values, v)
+			kept[c] += v
+		}
+	}
+
+	for i := range values {
+		values[i] /= kept[cols[i]]
+	}
+
+	return graphblas.NewCSRMatrixFromTuples(s.Rows(), s.Columns(), rows, cols, values, nil)
+}
+
+// change the largest difference between the elements of s and m
+func change(s, m *graphblas.CSRMatrix[float64]) float64 {
+	delta := 0.0
+	for iterator := s.Enumerate(); iterator.HasNext(); {
+		r, c, v := iterator.Next()
+		delta = math.Max(delta, math.Abs(v-m.At(r, c)))
+	}
+	for iterator := m.Enumerate(); iterator.HasNext(); {
+		r, c, v := iterator.Next()
+		delta = math.Max(delta, math.Abs(v-s.At(r, c)))
+	}
+	return delta
+}
+
+// attractors assigns each vertex, a column of the converged flow, to the row sending it the most flow,
+// attractors with flow between them form a single cluster
+func attractors(flow *graphblas.CSRMatrix[float64]) graphblas.Vector[int] {
+	n := flow.Columns()
+
+	best := make([]float64, n)
+	attractor := make([]int, n)
+	for i := range attractor {
+		attractor[i] = -1
+	}
+
+	parent := make([]int, n)
+	for i := range parent {
+		parent[i] = i
+	}
+
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	for iterator := flow.Enumerate(); iterator.HasNext(); {
+		r, c, v := iterator.Next()
+		if v > best[c] || (v == best[c] && r < attractor[c]) {
+			best[c] = v
+			attractor[c] = r
+		}
+
+		// flow between two attractors joins their clusters
+		if flow.At(r, r) > 0 && flow.At(c, c) > 0 {
+			if x, y := find(r), find(c); x != y {
+				parent[y] = x
+			}
+		}
+	}
+
+	roots := make([]int, n)
+	for c := 0; c < n; c++ {
+		roots[c] = c
+		if attractor[c] >= 0 {
+			roots[c] = find(attractor[c])
+		}
+	}
+
+	return partition.Relabel(roots)
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package clustering_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/clustering"
+)
+
+// two 4-cliques joined by the edge 3 - 4
+var cliques = [][]float64{
+	{0, 1, 1, 1, 0, 0, 0, 0},
+	{1, 0, 1, 1, 0, 0, 0, 0},
+	{1, 1, 0, 1, 0, 0, 0, 0},
+	{1, 1, 1, 0, 1, 0, 0, 0},
+	{0, 0, 0, 1, 0, 1, 1, 1},
+	{0, 0, 0, 0, 1, 0, 1, 1},
+	{0, 0, 0, 0, 1, 1, 0, 1},
+	{0, 0, 0, 0, 1, 1, 1, 0},
+}
+
+func TestMarkov(t *testing.T) {
+	want := []int{0, 0, 0, 0, 1, 1, 1, 1}
+
+	tests := []struct {
+		name    string
+		s       graphblas.Matrix[float64]
+		options clustering.MarkovOptions
+	}{
+		{
+			name: "CSRMatrix",
+			s:    graphblas.NewCSRMatrixFromArray(cliques),
+		},
+		{
+			name: "DenseMatrix",
+			s:    graphblas.NewDenseMatrixFromArrayN(cliques),
+		},
+		{
+			name:    "Expansion",
+			s:       graphblas.NewCSCMatrixFromArray(cliques),
+			options: clustering.MarkovOptions{Expansion: 3, Inflation: 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := clustering.Markov(context.Background(), tt.s, tt.options)
+			if err != nil {
+				t.Fatalf("%+v Markov error %+v", tt.name, err)
+			}
+
+			for i, w := range want {
+				if got.AtVec(i) != w {
+					t.Errorf("%+v Markov AtVec(%+v) = %+v, want %+v", tt.name, i, got.AtVec(i), w)
+				}
+			}
+		})
+	}
+}
+
+func TestMarkov_Isolated(t *testing.T) {
+	g := graphblas.NewCSRMatrixFromArray([][]float64{
+		{0, 1, 0},
+		{1, 0, 0},
+		{0, 0, 0},
+	})
+
+	got, err := clustering.Markov[float64](context.Background(), g, clustering.MarkovOptions{})
+	if err != nil {
+		t.Fatalf("Markov error %+v", err)
+	}
+
+	if got.AtVec(0) != got.AtVec(1) || got.AtVec(2) == got.AtVec(0) {
+		t.Errorf("Markov = %+v, want the isolated vertex in its own cluster", got)
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package clustering
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/constraints"
+)
+
+// ErrNegativeLabel is returned by Modularity when a vertex has a negative label
+var ErrNegativeLabel = errors.New("clustering: negative label")
+
+// Modularity of the clusters given by labels over the weighted symmetric adjacency matrix a, the fraction of the
+// weight inside the clusters less the fraction expected if the edges were placed at random keeping the degrees
+//
+//	Q = Σ in(c) / 2m - (tot(c) / 2m)²
+//
+// where in(c) is the weight of the edges inside cluster c counted from both ends, tot(c) the sum of the degrees
+// of its vertices and 2m the sum of the degrees of the graph, both come from the mxm of a with the label assignment matrix,
+// labels must hold a non-negative label for each vertex
+func Modularity[T constraints.Number](ctx context.Context, a graphblas.Matrix[T], labels graphblas.Vector[int]) (float64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	if labels.Length() != a.Rows() {
+		return 0, fmt.Errorf("clustering: %d labels for %d vertices", labels.Length(), a.Rows())
+	}
+
+	g := graphblas.Structure[T, float64](ctx, a, graphblas.StructureOptions{})
+	n := g.Rows()
+
+	k := 0
+	vertices := make([]int, n)
+	clusters := make([]int, n)
+	ones := make([]float64, n)
+	for v := 0; v < n; v++ {
+		vertices[v] = v
+		clusters[v] = labels.AtVec(v)
+		ones[v] = 1
+		if clusters[v] < 0 {
+			return 0, fmt.Errorf("%w: vertex %d has label %d", ErrNegativeLabel, v, clusters[v])
+		}
+		if clusters[v] >= k {
+			k = clusters[v] + 1
+		}
+	}
+
+	// the weight from each vertex into each cluster
+	assignment := graphblas.NewCSRMatrixFromTuples(n, k, vertices, clusters, ones, nil)
+	weights := graphblas.NewCSRMatrix[float64](n, k)
+	graphblas.MatrixMatrixMultiply[float64](ctx, g, assignment, nil, weights)
+
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	inside := make([]float64, k)
+	total := make([]float64, k)
+	m2 := 0.0
+	for iterator := weights.Enumerate(); iterator.HasNext(); {
+		r, c, v := iterator.Next()
+		total[clusters[r]] += v
+		m2 += v
+		if c == clusters[r] {
+			inside[c] += v
+		}
+	}
+
+	if m2 == 0 {
+		return 0, nil
+	}
+
+	q := 0.0
+	for c := 0; c < k; c++ {
+		q += inside[c]/m2 - (total[c]/m2)*(total[c]/m2)
+	}
+
+	return q, nil
+}
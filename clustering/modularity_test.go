@@ -0,0 +1,67 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package clustering_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/clustering"
+)
+
+func TestModularity(t *testing.T) {
+	tests := []struct {
+		name   string
+		labels []int
+		want   float64
+	}{
+		{
+			name:   "Cliques",
+			labels: []int{0, 0, 0, 0, 1, 1, 1, 1},
+			want:   11.0 / 26,
+		},
+		{
+			name:   "Single",
+			labels: []int{0, 0, 0, 0, 0, 0, 0, 0},
+			want:   0,
+		},
+		{
+			name:   "Singletons",
+			labels: []int{0, 1, 2, 3, 4, 5, 6, 7},
+			want:   -86.0 / 676,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			labels := graphblas.NewDenseVectorFromArrayN(tt.labels)
+			got, err := clustering.Modularity[float64](context.Background(), graphblas.NewCSRMatrixFromArray(cliques), labels)
+			if err != nil {
+				t.Fatalf("%+v Modularity error %+v", tt.name, err)
+			}
+
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("%+v Modularity = %+v, want %+v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestModularity_Labels(t *testing.T) {
+	a := graphblas.NewCSRMatrixFromArray(cliques)
+
+	_, err := clustering.Modularity[float64](context.Background(), a, graphblas.NewDenseVectorFromArrayN([]int{0, 0, 0, -1, 1, 1, 1, 1}))
+	if !errors.Is(err, clustering.ErrNegativeLabel) {
+		t.Errorf("Modularity error = %+v, want %+v", err, clustering.ErrNegativeLabel)
+	}
+
+	_, err = clustering.Modularity[float64](context.Background(), a, graphblas.NewDenseVectorFromArrayN([]int{0, 0, 0}))
+	if err == nil {
+		t.Errorf("Modularity expected an error for too few labels")
+	}
+}
@@ -1 +1,126 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
 package clustering
+
+import (
+	"context"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/constraints"
+	"github.com/rossmerr/graphblas/internal/partition"
+)
+
+// PeerPressureOptions the zero value of each field uses its default
+type PeerPressureOptions struct {
+	// MaxIterations defaults to 100
+	MaxIterations int
+}
+
+func (s PeerPressureOptions) withDefaults() PeerPressureOptions {
+	if s.MaxIterations == 0 {
+		s.MaxIterations = 100
+	}
+	return s
+}
+
+// PeerPressure clusters the vertices of the adjacency matrix a, each vertex starts in its own cluster and
+// repeatedly joins the cluster with the most weight among its neighbours and itself, returning the cluster
+// of each vertex numbered from 0 in order of the first vertex in each cluster and the iterations run
+//
+// the votes are the mxm of a with the n × n cluster assignment matrix C, where C[j, k] is 1 when vertex j
+// is in cluster k, each row of the votes is reduced to its maximum and ties go to the lowest cluster
+func PeerPressure[T constraints.Number](ctx context.Context, a graphblas.Matrix[T], options PeerPressureOptions) (graphblas.Vector[int], int, error) {
+	options = options.withDefaults()
+	n := a.Rows()
+
+	rows := []int{}
+	cols := []int{}
+	values := []float64{}
+	for iterator := a.Enumerate(); iterator.HasNext(); {
+		r, c, v := iterator.Next()
+		if !graphblas.IsZero(v) && r != c {
+			rows = append(rows, r)
+			cols = append(cols, c)
+			values = append(values, float64(v))
+		}
+	}
+
+	// each vertex votes for its own cluster
+	for i := 0; i < n; i++ {
+		rows = append(rows, i)
+		cols = append(cols, i)
+		values = append(values, 1)
+	}
+
+	g := graphblas.NewCSRMatrixFromTuples(n, n, rows, cols, values, nil)
+
+	labels := make([]int, n)
+	for i := range labels {
+		labels[i] = i
+	}
+
+	vertices := make([]int, n)
+	ones := make([]float64, n)
+	for i := range vertices {
+		vertices[i] = i
+		ones[i] = 1
+	}
+
+	iterations := 0
+	for iterations < options.MaxIterations {
+		if err := ctx.Err(); err != nil {
+			return nil, iterations, err
+		}
+
+		iterations++
+
+		assignment := graphblas.NewCSRMatrixFromTuples(n, n, vertices, labels, ones, nil)
+		votes := graphblas.NewCSRMatrix[float64](n, n)
+		graphblas.MatrixMatrixMultiply[float64](ctx, g, assignment, nil, votes)
+
+		if err := ctx.Err(); err != nil {
+			return nil, iterations, err
+		}
+
+		// the columns of votesᵀ are the rows of votes
+		voters := []int{}
+		clusters := []int{}
+		tallies := []float64{}
+		for iterator := votes.Enumerate(); iterator.HasNext(); {
+			r, c, v := iterator.Next()
+			voters = append(voters, r)
+			clusters = append(clusters, c)
+			tallies = append(tallies, v)
+		}
+		transposed := graphblas.NewCSCMatrixFromTuples(n, n, clusters, voters, tallies, nil)
+		max := graphblas.ReduceMatrixToVectorWithMonoID[float64](ctx, transposed, graphblas.DefaultMonoIDMaximum[float64](), nil)
+
+		next := make([]int, n)
+		for i := range next {
+			next[i] = -1
+		}
+		for i := range voters {
+			r, c := voters[i], clusters[i]
+			if tallies[i] == max.AtVec(r) && (next[r] == -1 || c < next[r]) {
+				next[r] = c
+			}
+		}
+
+		changed := false
+		for i, label := range next {
+			if label != labels[i] {
+				changed = true
+			}
+		}
+
+		labels = next
+		if !changed {
+			break
+		}
+	}
+
+	return partition.Relabel(labels), iterations, nil
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package clustering_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/clustering"
+)
+
+func TestPeerPressure(t *testing.T) {
+	tests := []struct {
+		name       string
+		s          graphblas.Matrix[float64]
+		options    clustering.PeerPressureOptions
+		want       []int
+		iterations int
+	}{
+		{
+			name:       "CSRMatrix",
+			s:          graphblas.NewCSRMatrixFromArray(cliques),
+			want:       []int{0, 0, 0, 0, 1, 1, 1, 1},
+			iterations: 3,
+		},
+		{
+			name:       "DenseMatrix",
+			s:          graphblas.NewDenseMatrixFromArrayN(cliques),
+			want:       []int{0, 0, 0, 0, 1, 1, 1, 1},
+			iterations: 3,
+		},
+		{
+			name:       "MaxIterations",
+			s:          graphblas.NewCSCMatrixFromArray(cliques),
+			options:    clustering.PeerPressureOptions{MaxIterations: 1},
+			want:       []int{0, 0, 0, 0, 1, 2, 2, 2},
+			iterations: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, iterations, err := clustering.PeerPressure(context.Background(), tt.s, tt.options)
+			if err != nil {
+				t.Fatalf("%+v PeerPressure error %+v", tt.name, err)
+			}
+
+			if iterations != tt.iterations {
+				t.Errorf("%+v PeerPressure iterations = %+v, want %+v", tt.name, iterations, tt.iterations)
+			}
+
+			for i, w := range tt.want {
+				if got.AtVec(i) != w {
+					t.Errorf("%+v PeerPressure AtVec(%+v) = %+v, want %+v", tt.name, i, got.AtVec(i), w)
+				}
+			}
+		})
+	}
+}
+
+func TestPeerPressure_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	g := graphblas.NewCSRMatrixFromArray(cliques)
+	if _, _, err := clustering.PeerPressure[float64](ctx, g, clustering.PeerPressureOptions{}); err == nil {
+		t.Errorf("PeerPressure error = nil, want %+v", context.Canceled)
+	}
+}
@@ -1 +1,401 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
 package clustering
+
+import (
+	"context"
+	"log"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/internal/partition"
+)
+
+// SpectralOptions the zero value of each field uses its default
+type SpectralOptions struct {
+	// Steps the size of the Lanczos subspace, more steps give more accurate eigenvectors, defaults to max(2k + 1, 30)
+	Steps int
+
+	// Seed for the Lanczos starting vector and the k-means centres
+	Seed int64
+
+	// MaxIterations of k-means, defaults to 100
+	MaxIterations int
+}
+
+func (s SpectralOptions) withDefaults(n, k int) SpectralOptions {
+	if s.Steps == 0 {
+		s.Steps = 2*k + 1
+		if s.Steps < 30 {
+			s.Steps = 30
+		}
+	}
+	if s.Steps > n {
+		s.Steps = n
+	}
+	if s.MaxIterations == 0 {
+		s.MaxIterations = 100
+	}
+	return s
+}
+
+// Spectral clusters the vertices of the symmetric adjacency matrix a into k clusters, returning the cluster of
+// each vertex numbered from 0 in order of the first vertex in each cluster and the n × k embedding
+//
+// the eigenvectors of the k smallest eigenvalues of the normalised Laplacian I - D^-½ a D^-½ are the eigenvectors
+// of the k largest eigenvalues of D^-½ a D^-½, found by Lanczos iteration using only mxv, the rows of the
+// eigenvectors are scaled to unit length and grouped by k-means
+func Spectral(ctx context.Context, a graphblas.Matrix[float64], k int, options SpectralOptions) (graphblas.Vector[int], graphblas.Matrix[float64], error) {
+	n := a.Rows()
+	if k < 1 || k > n {
+		log.Panicf("Can not find %+v clusters in %+v vertices", k, n)
+	}
+
+	options = options.withDefaults(n, k)
+	random := rand.New(rand.NewSource(options.Seed))
+
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	degree := make([]float64, n)
+	for iterator := a.Enumerate(); iterator.HasNext(); {
+		r, _, v := iterator.Next()
+		degree[r] += v
+	}
+
+	rows := []int{}
+	cols := []int{}
+	values := []float64{}
+	for iterator := a.Enumerate(); iterator.HasNext(); {
+		r, c, v := iterator.Next()
+		if v != 0 && degree[r] > 0 && degree[c] > 0 {
+			rows = append(rows, r)
+			cols = append(cols, c)
+			values = append(values, v/math.Sqrt(degree[r]*degree[c]))
+		}
+	}
+	normalised := graphblas.NewCSRMatrixFromTuples(n, n, rows, cols, values, nil)
+
+	vectors, err := lanczos(ctx, normalised, k, options.Steps, random)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	embedding := graphblas.NewDenseMatrixN[float64](n, k)
+	points := make([][]float64, n)
+	for i := range points {
+		points[i] = make([]float64, k)
+		norm := 0.0
+		for j := 0; j < k; j++ {
+			norm += vectors[j][i] * vectors[j][i]
+		}
+		norm = math.Sqrt(norm)
+
+		for j := 0; j < k; j++ {
+			if norm > 0 {
+				points[i][j] = vectors[j][i] / norm
+			}
+			embedding.Set(i, j, points[i][j])
+		}
+	}
+
+	labels, err := kMeans(ctx, points, k, options.MaxIterations, random)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return partition.Relabel(labels), embedding, nil
+}
+
+// lanczos returns the eigenvectors of the k largest eigenvalues of the symmetric matrix s from a Krylov subspace
+// of the given number of steps, each new basis vector is reorthogonalised against the whole basis and when the
+// subspace stops growing a new random vector is started so repeated eigenvalues are found
+func lanczos(ctx context.Context, s graphblas.Matrix[float64], k, steps int, random *rand.Rand) ([][]float64, error) {
+	n := s.Rows()
+	basis := [][]float64{}
+	alpha := []float64{}
+	beta := []float64{}
+
+	q := orthogonal(basis, n, random)
+	x := graphblas.NewDenseVectorN[float64](n)
+	var w graphblas.Vector[float64] = graphblas.NewDenseVectorN[float64](n)
+
+	for j := 0; j < steps && q != nil; j++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		basis = append(basis, q)
+		for i, v := range q {
+			x.SetVec(i, v)
+		}
+		graphblas.MatrixVectorMultiply[float64](ctx, s, x, nil, w)
+
+		next := make([]float64, n)
+		for i := range next {
+			next[i] = w.AtVec(i)
+		}
+
+		alpha = append(alpha, dot(next, q))
+
+		// twice is enough to keep the basis orthogonal to working precision
+		for pass := 0; pass < 2; pass++ {
+			for _, b := range basis {
+				axpy(-dot(next, b), b, next)
+			}
+		}
+
+		if j == steps-1 {
+			break
+		}
+
+		norm := math.Sqrt(dot(next, next))
+		if norm > 1e-10 {
+			for i := range next {
+				next[i] /= norm
+			}
+			beta = append(beta, norm)
+			q = next
+		} else {
+			beta = append(beta, 0)
+			q = orthogonal(basis, n, random)
+		}
+	}
+
+	m := len(basis)
+	tridiagonal := make([][]float64, m)
+	for i := range tridiagonal {
+		tridiagonal[i] = make([]float64, m)
+		tridiagonal[i][i] = alpha[i]
+	}
+	for i := 0; i+1 < m; i++ {
+		tridiagonal[i+1][i] = beta[i]
+		tridiagonal[i][i+1] = beta[i]
+	}
+
+	values, ritz := jacobi(tridiagonal)
+
+	order := make([]int, m)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return values[order[i]] > values[order[j]]
+	})
+
+	vectors := make([][]float64, k)
+	for j := 0; j < k && j < m; j++ {
+		vectors[j] = make([]float64, n)
+		for i, b := range basis {
+			axpy(ritz[i][order[j]], b, vectors[j])
+		}
+	}
+	for j := m; j < k; j++ {
+		vectors[j] = make([]float64, n)
+	}
+
+	return vectors, nil
+}
+
+// orthogonal returns a random unit vector orthogonal to the basis, nil when the basis spans the whole space
+func orthogonal(basis [][]float64, n int, random *rand.Rand) []float64 {
+	if len(basis) >= n {
+		return nil
+	}
+
+	for attempt := 0; attempt < 10; attempt++ {
+		q := make([]float64, n)
+		for i := range q {
+			q[i] = random.Float64() - 0.5
+		}
+
+		for pass := 0; pass < 2; pass++ {
+			for _, b := range basis {
+				axpy(-dot(q, b), b, q)
+			}
+		}
+
+		if norm := math.Sqrt(dot(q, q)); norm > 1e-10 {
+			for i := range q {
+				q[i] /= norm
+			}
+			return q
+		}
+	}
+
+	return nil
+}
+
+// jacobi returns the eigenvalues of the symmetric matrix s and its eigenvectors as columns, by cyclic Jacobi rotations
+func jacobi(s [][]float64) ([]float64, [][]float64) {
+	m := len(s)
+	a := make([][]float64, m)
+	v := make([][]float64, m)
+	for i := range a {
+		a[i] = append([]float64{}, s[i]...)
+		v[i] = make([]float64, m)
+		v[i][i] = 1
+	}
+
+	for sweep := 0; sweep < 100; sweep++ {
+		off := 0.0
+		for p := 0; p < m; p++ {
+			for q := p + 1; q < m; q++ {
+				off += a[p][q] * a[p][q]
+			}
+		}
+		if off < 1e-22 {
+			break
+		}
+
+		for p := 0; p < m; p++ {
+			for q := p + 1; q < m; q++ {
+				if math.Abs(a[p][q]) < 1e-300 {
+					continue
+				}
+
+				theta := (a[q][q] - a[p][p]) / (2 * a[p][q])
+				t := 1 / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+				if theta < 0 {
+					t = -t
+				}
+				c := 1 / math.Sqrt(t*t+1)
+				sn := t * c
+
+				for r := 0; r < m; r++ {
+					arp, arq := a[r][p], a[r][q]
+					a[r][p] = c*arp - sn*arq
+					a[r][q] = sn*arp + c*arq
+				}
+				for r := 0; r < m; r++ {
+					apr, aqr := a[p][r], a[q][r]
+					a[p][r] = c*apr - sn*aqr
+					a[q][r] = sn*apr + c*aqr
+				}
+				for r := 0; r < m; r++ {
+					vrp, vrq := v[r][p], v[r][q]
+					v[r][p] = c*vrp - sn*vrq
+					v[r][q] = sn*vrp + c*vrq
+				}
+			}
+		}
+	}
+
+	values := make([]float64, m)
+	for i := range values {
+		values[i] = a[i][i]
+	}
+
+	return values, v
+}
+
+// kMeans groups the points into k clusters by Lloyd iteration from k-means++ centres
+func kMeans(ctx context.Context, points [][]float64, k, maxIterations int, random *rand.Rand) ([]int, error) {
+	n := len(points)
+
+	centres := [][]float64{append([]float64{}, points[random.Intn(n)]...)}
+	nearest := make([]float64, n)
+	for len(centres) < k {
+		total := 0.0
+		for i, p := range points {
+			nearest[i] = math.Inf(1)
+			for _, c := range centres {
+				nearest[i] = math.Min(nearest[i], squared(p, c))
+			}
+			total += nearest[i]
+		}
+
+		// every point sits on a centre so any will do
+		next := random.Intn(n)
+		if total > 0 {
+			target := random.Float64() * total
+			for i, d := range nearest {
+				if target -= d; target <= 0 {
+					next = i
+					break
+				}
+			}
+		}
+
+		centres = append(centres, append([]float64{}, points[next]...))
+	}
+
+	labels := make([]int, n)
+	for i := range labels {
+		labels[i] = -1
+	}
+
+	for iteration := 0; iteration < maxIterations; iteration++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		changed := false
+		for i, p := range points {
+			best := 0
+			for j := 1; j < k; j++ {
+				if squared(p, centres[j]) < squared(p, centres[best]) {
+					best = j
+				}
+			}
+			if labels[i] != best {
+				labels[i] = best
+				changed = true
+			}
+		}
+
+		if !changed {
+			break
+		}
+
+		counts := make([]int, k)
+		sums := make([][]float64, k)
+		for j := range sums {
+			sums[j] = make([]float64, len(points[0]))
+		}
+		for i, p := range points {
+			counts[labels[i]]++
+			axpy(1, p, sums[labels[i]])
+		}
+
+		// an empty cluster keeps its centre
+		for j := range centres {
+			if counts[j] > 0 {
+				for d := range sums[j] {
+					centres[j][d] = sums[j][d] / float64(counts[j])
+				}
+			}
+		}
+	}
+
+	return labels, nil
+}
+
+func dot(x, y []float64) float64 {
+	sum := 0.0
+	for i := range x {
+		sum += x[i] * y[i]
+	}
+	return sum
+}
+
+// axpy adds a x onto y
+func axpy(a float64, x, y []float64) {
+	for i := range x {
+		y[i] += a * x[i]
+	}
+}
+
+func squared(x, y []float64) float64 {
+	sum := 0.0
+	for i := range x {
+		sum += (x[i] - y[i]) * (x[i] - y[i])
+	}
+	return sum
+}
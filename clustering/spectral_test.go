@@ -0,0 +1,81 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package clustering_test
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/clustering"
+)
+
+func TestSpectral(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       graphblas.Matrix[float64]
+		k       int
+		options clustering.SpectralOptions
+		want    []int
+	}{
+		{
+			name: "CSRMatrix",
+			s:    graphblas.NewCSRMatrixFromArray(cliques),
+			k:    2,
+			want: []int{0, 0, 0, 0, 1, 1, 1, 1},
+		},
+		{
+			name:    "Steps",
+			s:       graphblas.NewCSCMatrixFromArray(cliques),
+			k:       2,
+			options: clustering.SpectralOptions{Steps: 4, Seed: 3},
+			want:    []int{0, 0, 0, 0, 1, 1, 1, 1},
+		},
+		{
+			name: "Components",
+			s: graphblas.NewCSRMatrixFromArray([][]float64{
+				{0, 1, 1, 0, 0, 0},
+				{1, 0, 1, 0, 0, 0},
+				{1, 1, 0, 0, 0, 0},
+				{0, 0, 0, 0, 1, 0},
+				{0, 0, 0, 1, 0, 0},
+				{0, 0, 0, 0, 0, 0},
+			}),
+			k:    3,
+			want: []int{0, 0, 0, 1, 1, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, embedding, err := clustering.Spectral(context.Background(), tt.s, tt.k, tt.options)
+			if err != nil {
+				t.Fatalf("%+v Spectral error %+v", tt.name, err)
+			}
+
+			for i, w := range tt.want {
+				if got.AtVec(i) != w {
+					t.Errorf("%+v Spectral AtVec(%+v) = %+v, want %+v", tt.name, i, got.AtVec(i), w)
+				}
+			}
+
+			if embedding.Rows() != tt.s.Rows() || embedding.Columns() != tt.k {
+				t.Errorf("%+v Spectral embedding %+vx%+v, want %+vx%+v", tt.name, embedding.Rows(), embedding.Columns(), tt.s.Rows(), tt.k)
+			}
+
+			// the rows of the embedding are scaled to unit length
+			for r := 0; r < embedding.Rows(); r++ {
+				norm := 0.0
+				for c := 0; c < embedding.Columns(); c++ {
+					norm += embedding.At(r, c) * embedding.At(r, c)
+				}
+				if math.Abs(norm-1) > 1e-6 {
+					t.Errorf("%+v Spectral embedding row %+v norm = %+v, want 1", tt.name, r, norm)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package colouring
+
+import (
+	"context"
+	"math/rand"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/constraints"
+	"github.com/rossmerr/graphblas/unaryop"
+)
+
+// JonesPlassmann colours the vertices of the undirected graph with adjacency matrix a so no two neighbours share
+// a colour, returns the colour of each vertex numbered from 0 and the number of colours, self-loops and weights are ignored
+//
+// each round finds a maximal independent set of the uncoloured vertices with Luby and gives it the next colour,
+// the vertices of a colour share no edges so they can be updated in parallel
+func JonesPlassmann[T constraints.Number](ctx context.Context, a graphblas.Matrix[T], options Options) (graphblas.Vector[int], int, error) {
+	s := graphblas.Structure[T, float64](ctx, a, graphblas.StructureOptions{Symmetrize: true, RemoveLoops: true, Pattern: true})
+	n := s.Rows()
+	rnd := rand.New(rand.NewSource(options.Seed))
+
+	colours := graphblas.NewDenseVectorN[int](n)
+	remaining := make([]bool, n)
+	uncoloured := n
+	for v := range remaining {
+		remaining[v] = true
+	}
+
+	// keep the edges between the uncoloured vertices
+	keep := unaryop.NewSelectOp(func(r, c int, value float64) bool {
+		return remaining[r] && remaining[c]
+	})
+
+	colour := 0
+	for ; uncoloured > 0; colour++ {
+		if err := ctx.Err(); err != nil {
+			return nil, 0, err
+		}
+
+		sub := graphblas.NewCSRMatrix[float64](n, n)
+		graphblas.Select[float64](ctx, s, nil, keep, sub)
+
+		member, err := independent(ctx, sub, remaining, rnd)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		for v, ok := range member {
+			if ok {
+				colours.SetVec(v, colour)
+				remaining[v] = false
+				uncoloured--
+			}
+		}
+	}
+
+	return colours, colour, nil
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package colouring_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/colouring"
+)
+
+func TestJonesPlassmann(t *testing.T) {
+	// two triangles sharing vertex 2
+	bowtie := graphblas.NewCSRMatrixFromArray([][]float64{
+		{0, 1, 1, 0, 0},
+		{1, 0, 1, 0, 0},
+		{1, 1, 0, 1, 1},
+		{0, 0, 1, 0, 1},
+		{0, 0, 1, 1, 0},
+	})
+
+	tests := []struct {
+		name    string
+		g       graphblas.Matrix[float64]
+		seed    int64
+		atLeast int
+	}{
+		{
+			name:    "Bowtie",
+			g:       bowtie,
+			atLeast: 3,
+		},
+		{
+			name:    "Random",
+			g:       random(100, 300, 1),
+			seed:    1,
+			atLeast: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			colours, count, err := colouring.JonesPlassmann[float64](context.Background(), tt.g, colouring.Options{Seed: tt.seed})
+			if err != nil {
+				t.Fatalf("%+v JonesPlassmann error %+v", tt.name, err)
+			}
+
+			n := tt.g.Rows()
+			maxDegree := 0
+			for u := 0; u < n; u++ {
+				degree := 0
+				for v := 0; v < n; v++ {
+					if tt.g.At(u, v) != 0 {
+						degree++
+					}
+				}
+				if degree > maxDegree {
+					maxDegree = degree
+				}
+			}
+
+			if count < tt.atLeast || count > maxDegree+1 {
+				t.Errorf("%+v JonesPlassmann colours = %+v, want between %+v and %+v", tt.name, count, tt.atLeast, maxDegree+1)
+			}
+
+			for u := 0; u < n; u++ {
+				if c := colours.AtVec(u); c < 0 || c >= count {
+					t.Errorf("%+v JonesPlassmann AtVec(%+v) = %+v, want below %+v", tt.name, u, c, count)
+				}
+				for v := 0; v < n; v++ {
+					if tt.g.At(u, v) != 0 && colours.AtVec(u) == colours.AtVec(v) {
+						t.Errorf("%+v JonesPlassmann neighbours %+v and %+v share colour %+v", tt.name, u, v, colours.AtVec(u))
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+// Package colouring splits the vertices of an undirected graph into independent sets
+package colouring
+
+import (
+	"context"
+	"math/rand"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/binaryop"
+	"github.com/rossmerr/graphblas/constraints"
+	"github.com/rossmerr/graphblas/unaryop"
+)
+
+// Options the zero value uses the seed 0
+type Options struct {
+	// Seed for the random weights, the same seed gives the same result
+	Seed int64
+}
+
+// Luby a maximal independent set of the undirected graph with adjacency matrix a, no two vertices of the set
+// are neighbours and every other vertex has a neighbour in the set, self-loops and weights are ignored
+//
+// each round gives the candidates random weights favouring low degree, the candidates heavier than all their
+// candidate neighbours by a masked max.second mxv are selected into the set and they and their neighbours
+// stop being candidates
+func Luby[T constraints.Number](ctx context.Context, a graphblas.Matrix[T], options Options) (graphblas.VectorLogial[bool], error) {
+	s := graphblas.Structure[T, float64](ctx, a, graphblas.StructureOptions{Symmetrize: true, RemoveLoops: true, Pattern: true})
+	n := s.Rows()
+
+	remaining := make([]bool, n)
+	for v := range remaining {
+		remaining[v] = true
+	}
+
+	member, err := independent(ctx, s, remaining, rand.New(rand.NewSource(options.Seed)))
+	if err != nil {
+		return nil, err
+	}
+
+	result := graphblas.NewDenseVector[bool](n)
+	for v, ok := range member {
+		result.SetVec(v, ok)
+	}
+
+	return result, nil
+}
+
+// independent a maximal independent set of the remaining vertices, s only holds the edges between them
+func independent(ctx context.Context, s *graphblas.CSRMatrix[float64], remaining []bool, rnd *rand.Rand) ([]bool, error) {
+	n := s.Rows()
+	member := make([]bool, n)
+
+	degree := make([]int, n)
+	for iterator := s.Enumerate(); iterator.HasNext(); {
+		r, _, _ := iterator.Next()
+		degree[r]++
+	}
+
+	// the isolated vertices join the set straight away
+	candidate := graphblas.NewDenseVectorN[float64](n)
+	candidates := 0
+	for v := 0; v < n; v++ {
+		if !remaining[v] {
+			continue
+		}
+		if degree[v] == 0 {
+			member[v] = true
+		} else {
+			candidate.SetVec(v, 1)
+			candidates++
+		}
+	}
+
+	score := graphblas.NewDenseVectorN[float64](n)
+	heaviest := graphblas.NewDenseVectorN[float64](n)
+	semiring := maxSecond()
+	heavier := unaryop.NewSelectOp(func(r, c int, value float64) bool {
+		return value > heaviest.AtVec(r)
+	})
+
+	for candidates > 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		for v := 0; v < n; v++ {
+			weight := 0.0
+			if candidate.AtVec(v) > 0 {
+				weight = 0.0001 + rnd.Float64()/float64(1+2*degree[v])
+			}
+			score.SetVec(v, weight)
+		}
+
+		// the complement of candidate skips the vertices no longer in the running
+		graphblas.MatrixVectorMultiplyWithSemiring[float64](ctx, s, score, semiring, graphblas.NewComplement(candidate), heaviest)
+
+		selected := graphblas.NewSparseVector[float64](n)
+		graphblas.Select[float64](ctx, score, graphblas.NewComplement(candidate), heavier, selected)
+
+		neighbours := graphblas.NewSparseVector[float64](n)
+		graphblas.MatrixVectorMultiply[float64](ctx, s, selected, graphblas.NewComplement(candidate), neighbours)
+
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		for iterator := selected.Enumerate(); iterator.HasNext(); {
+			v, _, _ := iterator.Next()
+			member[v] = true
+			candidate.SetVec(v, 0)
+			candidates--
+		}
+
+		for iterator := neighbours.Enumerate(); iterator.HasNext(); {
+			v, _, _ := iterator.Next()
+			if candidate.AtVec(v) > 0 {
+				candidate.SetVec(v, 0)
+				candidates--
+			}
+		}
+	}
+
+	return member, nil
+}
+
+// maxSecond the max.second semiring over the positive weights, zero is the weight of no neighbour
+func maxSecond() binaryop.Semiring[float64] {
+	return binaryop.NewSemiring(graphblas.DefaultMonoIDMaximum[float64](), binaryop.SecondArgument[float64]())
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package colouring_test
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/colouring"
+)
+
+// a random undirected graph with an isolated vertex 0
+func random(n, edges int, seed int64) *graphblas.CSRMatrix[float64] {
+	rnd := rand.New(rand.NewSource(seed))
+	g := graphblas.NewCSRMatrix[float64](n, n)
+	for i := 0; i < edges; i++ {
+		u, v := 1+rnd.Intn(n-1), 1+rnd.Intn(n-1)
+		if u != v {
+			g.Set(u, v, 1)
+			g.Set(v, u, 1)
+		}
+	}
+	return g
+}
+
+func TestLuby(t *testing.T) {
+	clique := graphblas.NewCSRMatrixFromArray([][]float64{
+		{0, 1, 1, 1},
+		{1, 0, 1, 1},
+		{1, 1, 0, 1},
+		{1, 1, 1, 0},
+	})
+
+	tests := []struct {
+		name string
+		g    graphblas.Matrix[float64]
+		seed int64
+	}{
+		{
+			name: "Clique",
+			g:    clique,
+		},
+		{
+			name: "Random",
+			g:    random(100, 300, 1),
+			seed: 1,
+		},
+		{
+			name: "Seed",
+			g:    random(100, 300, 1),
+			seed: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := colouring.Luby[float64](context.Background(), tt.g, colouring.Options{Seed: tt.seed})
+			if err != nil {
+				t.Fatalf("%+v Luby error %+v", tt.name, err)
+			}
+
+			again, _ := colouring.Luby[float64](context.Background(), tt.g, colouring.Options{Seed: tt.seed})
+
+			n := tt.g.Rows()
+			for u := 0; u < n; u++ {
+				if got.AtVec(u) != again.AtVec(u) {
+					t.Errorf("%+v Luby AtVec(%+v) = %+v, want %+v with the same seed", tt.name, u, got.AtVec(u), again.AtVec(u))
+				}
+
+				covered := got.AtVec(u)
+				for v := 0; v < n; v++ {
+					if tt.g.At(u, v) == 0 {
+						continue
+					}
+					if got.AtVec(u) && got.AtVec(v) {
+						t.Errorf("%+v Luby neighbours %+v and %+v are both in the set", tt.name, u, v)
+					}
+					covered = covered || got.AtVec(v)
+				}
+
+				if !covered {
+					t.Errorf("%+v Luby vertex %+v has no neighbour in the set", tt.name, u)
+				}
+			}
+		})
+	}
+}
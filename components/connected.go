@@ -0,0 +1,106 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+// Package components splits a graph into its connected components
+package components
+
+import (
+	"context"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/constraints"
+)
+
+// ConnectedOptions the zero value expects a symmetric adjacency matrix
+type ConnectedOptions struct {
+	// Symmetrize finds the weakly connected components of a directed graph by adding the reverse of each edge
+	Symmetrize bool
+}
+
+// Connected components of the undirected graph with adjacency matrix a using FastSV, returns the component of each
+// vertex numbered from 0 in the order of their lowest vertex and the number of vertices in each component
+//
+// each vertex holds a parent in a forest of trees, every round finds the lowest grandparent among the neighbours of
+// each vertex with a min.second mxv then hooks the vertex and its parent onto it and shortcuts the trees to
+// their grandparents, stopping once no grandparent changes and every tree is a star rooted at its lowest vertex
+func Connected[T constraints.Number](ctx context.Context, a graphblas.Matrix[T], options ConnectedOptions) (ids, sizes graphblas.Vector[int], err error) {
+	n := a.Rows()
+	g := graphblas.Structure[T, int](ctx, a, graphblas.StructureOptions{Symmetrize: options.Symmetrize, RemoveLoops: true, Pattern: true})
+
+	parent := make([]int, n)
+	for u := range parent {
+		parent[u] = u
+	}
+
+	// the grandparents are one-based as zero is absent
+	grandparent := graphblas.NewDenseVectorN[int](n)
+	for u := 0; u < n; u++ {
+		grandparent.SetVec(u, u+1)
+	}
+
+	neighbour := graphblas.NewDenseVectorN[int](n)
+	semiring := graphblas.DefaultSemiringMinSecond[int]()
+
+	for changed := true; changed; {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+
+		// the lowest grandparent of the neighbours of each vertex, zero for a vertex without neighbours
+		graphblas.MatrixVectorMultiplyWithSemiring[int](ctx, g, grandparent, semiring, nil, neighbour)
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+
+		for u := 0; u < n; u++ {
+			m := neighbour.AtVec(u)
+			if m == 0 {
+				continue
+			}
+			m--
+
+			// stochastic hooking of the parent then aggressive hooking of the vertex
+			if p := parent[u]; m < parent[p] {
+				parent[p] = m
+			}
+			if m < parent[u] {
+				parent[u] = m
+			}
+		}
+
+		// shortcutting
+		for u := 0; u < n; u++ {
+			if gp := grandparent.AtVec(u) - 1; gp < parent[u] {
+				parent[u] = gp
+			}
+		}
+
+		changed = false
+		for u := 0; u < n; u++ {
+			gp := parent[parent[u]] + 1
+			if gp != grandparent.AtVec(u) {
+				grandparent.SetVec(u, gp)
+				changed = true
+			}
+		}
+	}
+
+	ids = graphblas.NewDenseVectorN[int](n)
+	count := []int{}
+	component := make([]int, n)
+	for u := 0; u < n; u++ {
+		// the root is the lowest vertex of its component so is numbered first
+		root := parent[u]
+		if root == u {
+			component[u] = len(count)
+			count = append(count, 0)
+		}
+		ids.SetVec(u, component[root])
+		count[component[root]]++
+	}
+
+	sizes = graphblas.NewDenseVectorFromArrayN(count)
+	return ids, sizes, nil
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package components_test
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/components"
+)
+
+func TestConnected(t *testing.T) {
+	// a path 0 - 3 - 5, a triangle 1 - 4 - 6 and the isolated vertex 2
+	undirected := graphblas.NewCSRMatrixFromArray([][]float64{
+		{0, 0, 0, 1, 0, 0, 0},
+		{0, 0, 0, 0, 1, 0, 1},
+		{0, 0, 0, 0, 0, 0, 0},
+		{1, 0, 0, 0, 0, 1, 0},
+		{0, 1, 0, 0, 0, 0, 1},
+		{0, 0, 0, 1, 0, 0, 0},
+		{0, 1, 0, 0, 1, 0, 0},
+	})
+
+	// the edges 5 → 3 → 0 and 6 → 4 → 1 are only reachable one way
+	directed := graphblas.NewCSRMatrixFromArray([][]float64{
+		{0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 1, 0, 0, 0, 0},
+		{1, 0, 0, 0, 0, 0, 0},
+		{0, 1, 0, 0, 0, 0, 0},
+		{0, 0, 0, 1, 0, 0, 0},
+		{0, 0, 0, 0, 1, 0, 0},
+	})
+
+	tests := []struct {
+		name    string
+		g       graphblas.Matrix[float64]
+		options components.ConnectedOptions
+		ids     []int
+		sizes   []int
+	}{
+		{
+			name:  "Undirected",
+			g:     undirected,
+			ids:   []int{0, 1, 2, 0, 1, 0, 1},
+			sizes: []int{3, 3, 1},
+		},
+		{
+			name:    "Symmetrize",
+			g:       directed,
+			options: components.ConnectedOptions{Symmetrize: true},
+			ids:     []int{0, 1, 2, 0, 1, 0, 1},
+			sizes:   []int{3, 3, 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids, sizes, err := components.Connected[float64](context.Background(), tt.g, tt.options)
+			if err != nil {
+				t.Fatalf("%+v Connected error %+v", tt.name, err)
+			}
+
+			for i := range tt.ids {
+				if ids.AtVec(i) != tt.ids[i] {
+					t.Errorf("%+v Connected ids AtVec(%+v) = %+v, want %+v", tt.name, i, ids.AtVec(i), tt.ids[i])
+				}
+			}
+
+			if sizes.Length() != len(tt.sizes) {
+				t.Fatalf("%+v Connected sizes Length = %+v, want %+v", tt.name, sizes.Length(), len(tt.sizes))
+			}
+			for i := range tt.sizes {
+				if sizes.AtVec(i) != tt.sizes[i] {
+					t.Errorf("%+v Connected sizes AtVec(%+v) = %+v, want %+v", tt.name, i, sizes.AtVec(i), tt.sizes[i])
+				}
+			}
+		})
+	}
+}
+
+func TestConnected_Random(t *testing.T) {
+	n := 2000
+	rnd := rand.New(rand.NewSource(1))
+	rows := []int{}
+	cols := []int{}
+	values := []float64{}
+
+	// union-find over the same edges for the expected components
+	root := make([]int, n)
+	for i := range root {
+		root[i] = i
+	}
+	var find func(int) int
+	find = func(u int) int {
+		if root[u] != u {
+			root[u] = find(root[u])
+		}
+		return root[u]
+	}
+
+	for i := 0; i < n/2; i++ {
+		u, v := rnd.Intn(n), rnd.Intn(n)
+		rows = append(rows, u)
+		cols = append(cols, v)
+		values = append(values, 1)
+		if ru, rv := find(u), find(v); ru != rv {
+			root[ru] = rv
+		}
+	}
+	g := graphblas.NewCSRMatrixFromTuples(n, n, rows, cols, values, nil)
+
+	ids, _, err := components.Connected[float64](context.Background(), g, components.ConnectedOptions{Symmetrize: true})
+	if err != nil {
+		t.Fatalf("Connected error %+v", err)
+	}
+
+	for u := 0; u < n; u++ {
+		for _, v := range []int{(u + 1) % n, rnd.Intn(n)} {
+			if (find(u) == find(v)) != (ids.AtVec(u) == ids.AtVec(v)) {
+				t.Errorf("Connected vertices %+v and %+v in components %+v and %+v", u, v, ids.AtVec(u), ids.AtVec(v))
+			}
+		}
+	}
+}
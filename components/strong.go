@@ -0,0 +1,253 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package components
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/binaryop"
+	"github.com/rossmerr/graphblas/constraints"
+	"github.com/rossmerr/graphblas/internal/partition"
+	"github.com/rossmerr/graphblas/unaryop"
+)
+
+// Strong components of the directed graph with adjacency matrix a, where a[i, j] is the edge i → j, returns the
+// component of each vertex numbered from 0 in the order of their lowest vertex
+//
+// forward-backward reachability, the vertices are split into partitions that no component crosses, every round first
+// trims the vertices without in-edges or out-edges inside their partition as components of their own, then searches
+// forward over a and backward over aᵀ from the lowest vertex of each partition, the vertices reached both ways
+// form its component and the rest split into those reached only forward, only backward or not at all
+func Strong[T constraints.Number](ctx context.Context, a graphblas.Matrix[T]) (graphblas.Vector[int], error) {
+	n := a.Rows()
+	g := graphblas.Structure[T, int](ctx, a, graphblas.StructureOptions{RemoveLoops: true, Pattern: true})
+	gt := graphblas.Structure[T, int](ctx, a, graphblas.StructureOptions{Transpose: true, RemoveLoops: true, Pattern: true})
+
+	label := make([]int, n)
+	part := make([]int, n)
+	for v := range label {
+		label[v] = -1
+	}
+	components := 0
+
+	// keep the edges between unlabelled vertices of the same partition
+	keep := unaryop.NewSelectOp(func(r, c int, value int) bool {
+		return label[r] < 0 && label[c] < 0 && part[r] == part[c]
+	})
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		out := graphblas.NewCSRMatrix[int](n, n)
+		in := graphblas.NewCSRMatrix[int](n, n)
+		graphblas.Select[int](ctx, g, nil, keep, out)
+		graphblas.Select[int](ctx, gt, nil, keep, in)
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		trimmed := components
+		components = trim(out, in, label, components)
+
+		pivots := map[int]int{}
+		sources := []int{}
+		for v := 0; v < n; v++ {
+			if _, ok := pivots[part[v]]; !ok && label[v] < 0 {
+				pivots[part[v]] = v
+				sources = append(sources, v)
+			}
+		}
+		if len(sources) == 0 {
+			break
+		}
+
+		// the trimmed vertices are dropped from the search
+		if components > trimmed {
+			graphblas.Select[int](ctx, g, nil, keep, out)
+			graphblas.Select[int](ctx, gt, nil, keep, in)
+		}
+
+		forward, err := reach(ctx, in, sources)
+		if err != nil {
+			return nil, err
+		}
+		backward, err := reach(ctx, out, sources)
+		if err != nil {
+			return nil, err
+		}
+
+		scc := map[int]int{}
+		split := map[[2]int]int{}
+		for v := 0; v < n; v++ {
+			if label[v] >= 0 {
+				continue
+			}
+
+			f, b := forward[v], backward[v]
+			if f && b {
+				id, ok := scc[part[v]]
+				if !ok {
+					id = components
+					scc[part[v]] = id
+					components++
+				}
+				label[v] = id
+				continue
+			}
+
+			key := [2]int{part[v], 0}
+			if f {
+				key[1] = 1
+			} else if b {
+				key[1] = 2
+			}
+			id, ok := split[key]
+			if !ok {
+				id = len(split)
+				split[key] = id
+			}
+			part[v] = id
+		}
+	}
+
+	return partition.Relabel(label), nil
+}
+
+// Condensation the directed acyclic graph of the strong components of a with their labels, the element
+// [i, j] is the number of edges from component i to component j, labels must hold a non-negative label for
+// each vertex of a
+func Condensation[T constraints.Number](ctx context.Context, a graphblas.Matrix[T], labels graphblas.Vector[int]) (*graphblas.CSRMatrix[int], error) {
+	if labels.Length() != a.Rows() || a.Columns() != a.Rows() {
+		return nil, fmt.Errorf("components: %d labels for a %dx%d matrix", labels.Length(), a.Rows(), a.Columns())
+	}
+
+	k := 0
+	for v := 0; v < labels.Length(); v++ {
+		if labels.AtVec(v) < 0 {
+			return nil, fmt.Errorf("components: vertex %d has label %d", v, labels.AtVec(v))
+		}
+		if labels.AtVec(v) >= k {
+			k = labels.AtVec(v) + 1
+		}
+	}
+
+	rows := []int{}
+	cols := []int{}
+	values := []int{}
+	for iterator := a.Enumerate(); iterator.HasNext(); {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		r, c, v := iterator.Next()
+		if graphblas.IsZero(v) {
+			continue
+		}
+
+		i, j := labels.AtVec(r), labels.AtVec(c)
+		if i != j {
+			rows = append(rows, i)
+			cols = append(cols, j)
+			values = append(values, 1)
+		}
+	}
+
+	return graphblas.NewCSRMatrixFromTuples(k, k, rows, cols, values, binaryop.Addition[int]()), nil
+}
+
+// trim labels each vertex without in-edges or out-edges as a component of its own, removing it may leave
+// its neighbours without any so they are trimmed in turn, returns the number of components
+func trim(out, in *graphblas.CSRMatrix[int], label []int, components int) int {
+	n := out.Rows()
+	successors := neighbours(out)
+	predecessors := neighbours(in)
+
+	outDegree := make([]int, n)
+	inDegree := make([]int, n)
+	queue := []int{}
+	for v := 0; v < n; v++ {
+		outDegree[v] = len(successors[v])
+		inDegree[v] = len(predecessors[v])
+		if label[v] < 0 && (outDegree[v] == 0 || inDegree[v] == 0) {
+			queue = append(queue, v)
+		}
+	}
+
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		if label[v] >= 0 {
+			continue
+		}
+
+		label[v] = components
+		components++
+
+		for _, w := range successors[v] {
+			if inDegree[w]--; inDegree[w] == 0 && label[w] < 0 {
+				queue = append(queue, w)
+			}
+		}
+		for _, w := range predecessors[v] {
+			if outDegree[w]--; outDegree[w] == 0 && label[w] < 0 {
+				queue = append(queue, w)
+			}
+		}
+	}
+
+	return components
+}
+
+// reach the vertices reached from the sources by a multi-source breadth-first search, row v of g holds the
+// vertices v is reached from
+func reach(ctx context.Context, g *graphblas.CSRMatrix[int], sources []int) ([]bool, error) {
+	n := g.Rows()
+	reached := make([]bool, n)
+
+	visited := graphblas.NewDenseVectorN[int](n)
+	var frontier graphblas.Vector[int] = graphblas.NewSparseVector[int](n)
+	for _, source := range sources {
+		visited.SetVec(source, 1)
+		frontier.SetVec(source, 1)
+		reached[source] = true
+	}
+
+	for frontier.Values() > 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		// the visited mask skips every vertex already reached
+		next := graphblas.NewSparseVector[int](n)
+		graphblas.MatrixVectorMultiply[int](ctx, g, frontier, visited, next)
+
+		for iterator := next.Enumerate(); iterator.HasNext(); {
+			v, _, _ := iterator.Next()
+			visited.SetVec(v, 1)
+			reached[v] = true
+		}
+
+		frontier = next
+	}
+
+	return reached, ctx.Err()
+}
+
+// neighbours the columns of each row of g
+func neighbours(g *graphblas.CSRMatrix[int]) [][]int {
+	result := make([][]int, g.Rows())
+	for iterator := g.Enumerate(); iterator.HasNext(); {
+		r, c, _ := iterator.Next()
+		result[r] = append(result[r], c)
+	}
+	return result
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package components_test
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/components"
+)
+
+// the cycles 0 → 1 → 2 → 0, 3 ⇄ 4 and 6 ⇄ 7 joined by 1 → 4, 2 → 3, 4 → 5 and 5 → 6
+var dependencies = [][]float64{
+	{0, 1, 0, 0, 0, 0, 0, 0},
+	{0, 0, 1, 0, 1, 0, 0, 0},
+	{1, 0, 0, 1, 0, 0, 0, 0},
+	{0, 0, 0, 0, 1, 0, 0, 0},
+	{0, 0, 0, 1, 0, 1, 0, 0},
+	{0, 0, 0, 0, 0, 0, 1, 0},
+	{0, 0, 0, 0, 0, 0, 0, 1},
+	{0, 0, 0, 0, 0, 0, 1, 0},
+}
+
+func TestStrong(t *testing.T) {
+	g := graphblas.NewCSRMatrixFromArray(dependencies)
+	labels, err := components.Strong[float64](context.Background(), g)
+	if err != nil {
+		t.Fatalf("Strong error %+v", err)
+	}
+
+	want := []int{0, 0, 0, 1, 1, 2, 3, 3}
+	for i := range want {
+		if labels.AtVec(i) != want[i] {
+			t.Errorf("Strong AtVec(%+v) = %+v, want %+v", i, labels.AtVec(i), want[i])
+		}
+	}
+}
+
+func TestStrong_Random(t *testing.T) {
+	n := 60
+	rnd := rand.New(rand.NewSource(1))
+	g := graphblas.NewCSRMatrix[float64](n, n)
+
+	// the transitive closure for the expected components
+	reach := make([][]bool, n)
+	for i := range reach {
+		reach[i] = make([]bool, n)
+		reach[i][i] = true
+	}
+	for i := 0; i < 90; i++ {
+		u, v := rnd.Intn(n), rnd.Intn(n)
+		g.Set(u, v, 1)
+		reach[u][v] = true
+	}
+	for k := 0; k < n; k++ {
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				reach[i][j] = reach[i][j] || (reach[i][k] && reach[k][j])
+			}
+		}
+	}
+
+	labels, err := components.Strong[float64](context.Background(), g)
+	if err != nil {
+		t.Fatalf("Strong error %+v", err)
+	}
+
+	for u := 0; u < n; u++ {
+		for v := 0; v < n; v++ {
+			if (reach[u][v] && reach[v][u]) != (labels.AtVec(u) == labels.AtVec(v)) {
+				t.Errorf("Strong vertices %+v and %+v in components %+v and %+v", u, v, labels.AtVec(u), labels.AtVec(v))
+			}
+		}
+	}
+}
+
+func TestCondensation(t *testing.T) {
+	g := graphblas.NewCSRMatrixFromArray(dependencies)
+	labels, err := components.Strong[float64](context.Background(), g)
+	if err != nil {
+		t.Fatalf("Strong error %+v", err)
+	}
+
+	dag, err := components.Condensation[float64](context.Background(), g, labels)
+	if err != nil {
+		t.Fatalf("Condensation error %+v", err)
+	}
+
+	want := [][]int{
+		{0, 2, 0, 0},
+		{0, 0, 1, 0},
+		{0, 0, 0, 1},
+		{0, 0, 0, 0},
+	}
+	if dag.Rows() != len(want) || dag.Columns() != len(want) {
+		t.Fatalf("Condensation = %+v × %+v, want %+v × %+v", dag.Rows(), dag.Columns(), len(want), len(want))
+	}
+	for r := range want {
+		for c := range want[r] {
+			if dag.At(r, c) != want[r][c] {
+				t.Errorf("Condensation At(%+v, %+v) = %+v, want %+v", r, c, dag.At(r, c), want[r][c])
+			}
+		}
+	}
+}
+
+func TestCondensation_Error(t *testing.T) {
+	g := graphblas.NewCSRMatrixFromArray(dependencies)
+
+	if _, err := components.Condensation[float64](context.Background(), g, graphblas.NewDenseVectorN[int](g.Rows()-1)); err == nil {
+		t.Errorf("Condensation expected an error for too few labels")
+	}
+
+	negative := graphblas.NewDenseVectorN[int](g.Rows())
+	negative.SetVec(0, -1)
+	if _, err := components.Condensation[float64](context.Background(), g, negative); err == nil {
+		t.Errorf("Condensation expected an error for a negative label")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := components.Condensation[float64](ctx, g, graphblas.NewDenseVectorN[int](g.Rows())); err != context.Canceled {
+		t.Errorf("Condensation error = %+v, want %+v", err, context.Canceled)
+	}
+}
@@ -9,6 +9,12 @@ type Number interface {
 	int | int8 | int16 | int32 | int64 | uint | uint8 | uint16 | uint32 | uint64 | uintptr | float32 | float64
 }
 
+// Float the floating point numbers, for algorithms that need +Inf such as the min-plus semiring
+type Float interface {
+	Number
+	float32 | float64
+}
+
 type String interface {
 	Logical
 }
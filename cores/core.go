@@ -0,0 +1,61 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package cores
+
+import (
+	"context"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/constraints"
+)
+
+// KCore the coreness of each vertex of the undirected graph with adjacency matrix a, the largest k for which the vertex
+// is in the k-core, the largest subgraph where every vertex has at least k neighbours, self-loops and weights are ignored
+//
+// the vertices are peeled by degree, each round reduces the rows of the remaining vertices over the remaining
+// vertices and removes those with fewer than k neighbours, once none are removed k is raised
+func KCore[T constraints.Number](ctx context.Context, a graphblas.Matrix[T]) (graphblas.Vector[int], error) {
+	s := graphblas.Structure[T, int](ctx, a, graphblas.StructureOptions{Symmetrize: true, RemoveLoops: true, Pattern: true})
+	n := s.Rows()
+
+	core := graphblas.NewDenseVectorN[int](n)
+	alive := graphblas.NewDenseVectorN[int](n)
+	for v := 0; v < n; v++ {
+		alive.SetVec(v, 1)
+	}
+
+	degree := graphblas.NewDenseVectorN[int](n)
+	remaining := n
+
+	for k := 1; remaining > 0; {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		// the complement of alive skips the rows of the removed vertices
+		graphblas.MatrixVectorMultiply[int](ctx, s, alive, graphblas.NewComplement(alive), degree)
+
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		removed := false
+		for v := 0; v < n; v++ {
+			if alive.AtVec(v) > 0 && degree.AtVec(v) < k {
+				alive.SetVec(v, 0)
+				core.SetVec(v, k-1)
+				remaining--
+				removed = true
+			}
+		}
+
+		if !removed {
+			k++
+		}
+	}
+
+	return core, nil
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package cores_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/cores"
+)
+
+func TestKCore(t *testing.T) {
+	want := []int{3, 3, 3, 3, 2, 2, 1, 0}
+
+	tests := []struct {
+		name string
+		s    graphblas.Matrix[float64]
+	}{
+		{
+			name: "CSRMatrix",
+			s:    graphblas.NewCSRMatrixFromArray(ring),
+		},
+		{
+			name: "DenseMatrix",
+			s:    graphblas.NewDenseMatrixFromArrayN(ring),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := cores.KCore(context.Background(), tt.s)
+			if err != nil {
+				t.Fatalf("%+v KCore error %+v", tt.name, err)
+			}
+
+			for i, w := range want {
+				if got.AtVec(i) != w {
+					t.Errorf("%+v KCore AtVec(%+v) = %+v, want %+v", tt.name, i, got.AtVec(i), w)
+				}
+			}
+		})
+	}
+}
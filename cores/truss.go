@@ -0,0 +1,65 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+// Package cores finds the densely connected subgraphs of an undirected graph
+package cores
+
+import (
+	"context"
+	"log"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/binaryop"
+	"github.com/rossmerr/graphblas/constraints"
+	"github.com/rossmerr/graphblas/unaryop"
+)
+
+// KTruss the k-truss of the undirected graph with adjacency matrix a, the largest subgraph where every edge is in
+// at least k - 2 triangles, each edge of the result holds the number of triangles it is in, self-loops and weights are ignored
+//
+// each round counts the support of every edge with the masked product (A·A) ⊙ A over the plus.pair semiring and
+// selects the edges with enough support, stopping once no edge is removed
+func KTruss[T constraints.Number](ctx context.Context, a graphblas.Matrix[T], k int) (*graphblas.CSRMatrix[int], error) {
+	if k < 3 {
+		log.Panicf("k '%+v' is invalid, a truss needs k of at least 3", k)
+	}
+
+	s := graphblas.Structure[T, int](ctx, a, graphblas.StructureOptions{Symmetrize: true, RemoveLoops: true, Pattern: true})
+	n := s.Rows()
+	semiring := plusPair()
+	supported := unaryop.NewSelectOp(func(r, c int, support int) bool {
+		return support >= k-2
+	})
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		// the complement of s skips every pair without an edge
+		support := graphblas.NewCSRMatrix[int](n, n)
+		graphblas.MatrixMatrixMultiplyWithSemiring[int](ctx, s, s, semiring, graphblas.NewComplement(s), support)
+
+		next := graphblas.NewCSRMatrix[int](n, n)
+		graphblas.Select[int](ctx, support, nil, supported, next)
+
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if next.Values() == s.Values() {
+			return next, nil
+		}
+
+		s = next
+	}
+}
+
+// plusPair the semiring counting the products, each pair of elements multiplies to 1
+func plusPair() binaryop.Semiring[int] {
+	return binaryop.NewSemiring(graphblas.DefaultMonoIDAddition[int](), binaryop.NewBinaryOp(func(in1, in2 int) int {
+		return 1
+	}))
+}
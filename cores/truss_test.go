@@ -0,0 +1,93 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package cores_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/cores"
+)
+
+// the clique 0-1-2-3, the triangle 3-4-5, the pendant vertex 6 off 5 and the isolated vertex 7,
+// each edge is given in one direction only
+var ring = [][]float64{
+	{0, 1, 1, 1, 0, 0, 0, 0},
+	{0, 0, 1, 1, 0, 0, 0, 0},
+	{0, 0, 0, 1, 0, 0, 0, 0},
+	{0, 0, 0, 0, 1, 1, 0, 0},
+	{0, 0, 0, 0, 0, 1, 0, 0},
+	{0, 0, 0, 0, 0, 0, 1, 0},
+	{0, 0, 0, 0, 0, 0, 0, 0},
+	{0, 0, 0, 0, 0, 0, 0, 0},
+}
+
+func TestKTruss(t *testing.T) {
+	clique := [][]int{
+		{0, 2, 2, 2, 0, 0, 0, 0},
+		{2, 0, 2, 2, 0, 0, 0, 0},
+		{2, 2, 0, 2, 0, 0, 0, 0},
+		{2, 2, 2, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0, 0},
+	}
+
+	triangles := [][]int{
+		{0, 2, 2, 2, 0, 0, 0, 0},
+		{2, 0, 2, 2, 0, 0, 0, 0},
+		{2, 2, 0, 2, 0, 0, 0, 0},
+		{2, 2, 2, 0, 1, 1, 0, 0},
+		{0, 0, 0, 1, 0, 1, 0, 0},
+		{0, 0, 0, 1, 1, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0, 0},
+	}
+
+	tests := []struct {
+		name string
+		k    int
+		want [][]int
+	}{
+		{
+			name: "3",
+			k:    3,
+			want: triangles,
+		},
+		{
+			name: "4",
+			k:    4,
+			want: clique,
+		},
+		{
+			name: "5",
+			k:    5,
+			want: make([][]int, 8),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := cores.KTruss[float64](context.Background(), graphblas.NewCSRMatrixFromArray(ring), tt.k)
+			if err != nil {
+				t.Fatalf("%+v KTruss error %+v", tt.name, err)
+			}
+
+			for r := 0; r < got.Rows(); r++ {
+				for c := 0; c < got.Columns(); c++ {
+					want := 0
+					if tt.want[r] != nil {
+						want = tt.want[r][c]
+					}
+					if got.At(r, c) != want {
+						t.Errorf("%+v KTruss At(%+v, %+v) = %+v, want %+v", tt.name, r, c, got.At(r, c), want)
+					}
+				}
+			}
+		})
+	}
+}
@@ -6,6 +6,8 @@
 package graphblas
 
 import (
+	"encoding/json"
+	"fmt"
 	"log"
 	"reflect"
 
@@ -419,3 +421,32 @@ func (s *CSCMatrix[T]) Element(r, c int) (b bool) {
 
 	return
 }
+
+// MarshalJSON encodes the matrix in the csc JSON format
+func (s *CSCMatrix[T]) MarshalJSON() ([]byte, error) {
+	j := newJSONMatrix[T](s.r, s.c, FormatCSC)
+	j.Pointers = s.colStart
+	j.Indices = s.rows
+	j.Values = s.values
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON decodes the matrix from the csc JSON format
+func (s *CSCMatrix[T]) UnmarshalJSON(data []byte) error {
+	j, err := unmarshalJSONMatrix[T](data, FormatCSC)
+	if err != nil {
+		return err
+	}
+
+	s.r = j.Rows
+	s.c = j.Cols
+	s.colStart = j.Pointers
+	s.rows = j.Indices
+	s.values = j.Values
+	return nil
+}
+
+// Format implements fmt.Formatter, see format for the verbs supported
+func (s *CSCMatrix[T]) Format(f fmt.State, verb rune) {
+	format[T](f, verb, s, layoutTriples)
+}
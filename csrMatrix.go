@@ -7,6 +7,8 @@ package graphblas
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"log"
 	"reflect"
 
@@ -322,7 +324,6 @@ type cSRMatrixIterator[T constraints.Number] struct {
 	last         int
 	c            int
 	r            int
-	cIndex       int
 	index        int
 	pointerStart int
 	pointerEnd   int
@@ -338,25 +339,16 @@ func (s *CSRMatrix[T]) iterator() *cSRMatrixIterator[T] {
 }
 
 func (s *cSRMatrixIterator[T]) next() {
-
 	for s.pointerStart == s.pointerEnd {
 		s.r++
 		s.pointerStart = s.matrix.rowStart[s.r]
 		s.pointerEnd = s.matrix.rowStart[s.r+1]
-		s.cIndex = s.matrix.cols[s.pointerStart]
 	}
 
-	for s.pointerStart < s.pointerEnd {
-		if s.matrix.cols[s.pointerStart] == s.cIndex {
-			s.index = s.pointerStart
-			s.pointerStart++
-			s.c = s.cIndex
-			s.cIndex++
-			s.last++
-			return
-		}
-		s.cIndex++
-	}
+	s.index = s.pointerStart
+	s.c = s.matrix.cols[s.index]
+	s.pointerStart++
+	s.last++
 }
 
 // HasNext checks the iterator has any more values
@@ -409,3 +401,32 @@ func (s *CSRMatrix[T]) Element(r, c int) (b bool) {
 
 	return
 }
+
+// MarshalJSON encodes the matrix in the csr JSON format
+func (s *CSRMatrix[T]) MarshalJSON() ([]byte, error) {
+	j := newJSONMatrix[T](s.r, s.c, FormatCSR)
+	j.Pointers = s.rowStart
+	j.Indices = s.cols
+	j.Values = s.values
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON decodes the matrix from the csr JSON format
+func (s *CSRMatrix[T]) UnmarshalJSON(data []byte) error {
+	j, err := unmarshalJSONMatrix[T](data, FormatCSR)
+	if err != nil {
+		return err
+	}
+
+	s.r = j.Rows
+	s.c = j.Cols
+	s.rowStart = j.Pointers
+	s.cols = j.Indices
+	s.values = j.Values
+	return nil
+}
+
+// Format implements fmt.Formatter, see format for the verbs supported
+func (s *CSRMatrix[T]) Format(f fmt.State, verb rune) {
+	format[T](f, verb, s, layoutTriples)
+}
@@ -1,6 +1,8 @@
 package graphblas
 
 import (
+	"math"
+
 	"github.com/rossmerr/graphblas/binaryop"
 	"github.com/rossmerr/graphblas/constraints"
 )
@@ -28,3 +30,26 @@ func DefaultMonoIDMaximum[T constraints.Number]() binaryop.MonoID[T] {
 	max := binaryop.Maximum[T]()
 	return binaryop.NewMonoID(d, max)
 }
+
+// DefaultSemiringPlusTimes the arithmetic semiring, sums of products
+func DefaultSemiringPlusTimes[T constraints.Number]() binaryop.Semiring[T] {
+	return binaryop.NewSemiring(DefaultMonoIDAddition[T](), binaryop.Multiplication[T]())
+}
+
+// DefaultSemiringMinPlus the tropical semiring, the minimum of sums with +Inf as the empty minimum
+func DefaultSemiringMinPlus[T constraints.Float]() binaryop.Semiring[T] {
+	min := binaryop.NewMonoID(T(math.Inf(1)), binaryop.Minimum[T]())
+	return binaryop.NewSemiring(min, binaryop.Addition[T]())
+}
+
+// DefaultSemiringMinFirst the min.first semiring, the minimum of the first operands with zero as the empty minimum
+// as the library treats zero as absent
+func DefaultSemiringMinFirst[T constraints.Number]() binaryop.Semiring[T] {
+	return binaryop.NewSemiring(binaryop.NewMonoID(Default[T](), binaryop.Minimum[T]()), binaryop.FirstArgument[T]())
+}
+
+// DefaultSemiringMinSecond the min.second semiring, the minimum of the second operands with zero as the empty minimum
+// as the library treats zero as absent
+func DefaultSemiringMinSecond[T constraints.Number]() binaryop.Semiring[T] {
+	return binaryop.NewSemiring(binaryop.NewMonoID(Default[T](), binaryop.Minimum[T]()), binaryop.SecondArgument[T]())
+}
@@ -7,6 +7,8 @@ package graphblas
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"log"
 
 	"github.com/rossmerr/graphblas/constraints"
@@ -364,3 +366,35 @@ func (s *DenseMatrixNumber[T]) Element(r, c int) bool {
 func (s *DenseMatrixNumber[T]) element(r, c int) bool {
 	return s.At(r, c) > Default[T]()
 }
+
+// MarshalJSON encodes the matrix in the dense JSON format
+func (s *DenseMatrix[T]) MarshalJSON() ([]byte, error) {
+	j := newJSONMatrix[T](s.r, s.c, FormatDense)
+	for _, row := range s.data {
+		j.Values = append(j.Values, row...)
+	}
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON decodes the matrix from the dense JSON format
+func (s *DenseMatrix[T]) UnmarshalJSON(data []byte) error {
+	j, err := unmarshalJSONMatrix[T](data, FormatDense)
+	if err != nil {
+		return err
+	}
+
+	*s = newMatrix[T](j.Rows, j.Cols, func(row []T, r int) {
+		copy(row, j.Values[r*j.Cols:])
+	})
+	return nil
+}
+
+// Format implements fmt.Formatter, see format for the verbs supported
+func (s *DenseMatrix[T]) Format(f fmt.State, verb rune) {
+	format[T](f, verb, s, layoutGrid)
+}
+
+// Format implements fmt.Formatter, see format for the verbs supported
+func (s *DenseMatrixNumber[T]) Format(f fmt.State, verb rune) {
+	format[T](f, verb, s, layoutGrid)
+}
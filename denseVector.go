@@ -7,6 +7,8 @@ package graphblas
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"log"
 
 	"github.com/rossmerr/graphblas/constraints"
@@ -353,3 +355,32 @@ func (s *denseVectorMap[T]) Map(f func(int, int, T) T) {
 func (s *DenseVectorNumber[T]) Element(r, c int) bool {
 	return s.AtVec(r) > Default[T]()
 }
+
+// MarshalJSON encodes the vector in the dense-vector JSON format
+func (s *DenseVector[T]) MarshalJSON() ([]byte, error) {
+	j := newJSONMatrix[T](s.l, 1, FormatDenseVector)
+	j.Values = append(j.Values, s.values...)
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON decodes the vector from the dense-vector JSON format
+func (s *DenseVector[T]) UnmarshalJSON(data []byte) error {
+	j, err := unmarshalJSONMatrix[T](data, FormatDenseVector)
+	if err != nil {
+		return err
+	}
+
+	s.l = j.Rows
+	s.values = j.Values
+	return nil
+}
+
+// Format implements fmt.Formatter, see format for the verbs supported
+func (s *DenseVector[T]) Format(f fmt.State, verb rune) {
+	format[T](f, verb, s, layoutVector)
+}
+
+// Format implements fmt.Formatter, see format for the verbs supported
+func (s *DenseVectorNumber[T]) Format(f fmt.State, verb rune) {
+	format[T](f, verb, s, layoutVector)
+}
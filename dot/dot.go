@@ -0,0 +1,103 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+// Package dot writes matrices as Graphviz DOT graphs
+package dot
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/constraints"
+	"github.com/rossmerr/graphblas/edgelist"
+	"github.com/rossmerr/graphblas/internal/render"
+)
+
+// Options controls what is written for each vertex and edge
+type Options[T constraints.Number] struct {
+	// Labels the vertex labels, when nil the indices are used
+	Labels *edgelist.Labels
+
+	// Undirected writes a graph rather than a digraph and only emits r <= c
+	Undirected bool
+
+	// Weights writes the edge weight as the edge label
+	Weights bool
+
+	// Colour fills each vertex with a colour picked by its value, e.g. a cluster ID
+	Colour graphblas.VectorLogial[T]
+
+	// Attributes written on each vertex by name
+	Attributes map[string]graphblas.VectorLogial[T]
+
+	// Threshold edges with a weight below are skipped, nil keeps every edge
+	Threshold *T
+
+	// Limit the maximum number of edges written, zero for no limit
+	Limit int
+}
+
+// Write emits s as a DOT graph, when Threshold or Limit drop edges only the vertices they touch are written
+func Write[T constraints.Number](ctx context.Context, w io.Writer, s graphblas.MatrixLogical[T], options Options[T]) error {
+	vertices, edges, err := render.Collect(ctx, s, options.Undirected, options.Threshold, options.Limit)
+	if err != nil {
+		return err
+	}
+
+	graph, connector := "digraph", "->"
+	if options.Undirected {
+		graph, connector = "graph", "--"
+	}
+
+	names := make([]string, 0, len(options.Attributes))
+	for name := range options.Attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	colours := render.NewColours[T]()
+
+	b := bufio.NewWriter(w)
+	fmt.Fprintf(b, "%s G {\n", graph)
+
+	for _, v := range vertices {
+		fmt.Fprintf(b, "\t%d [label=%s", v, quote(options.Labels.Label(v)))
+
+		if options.Colour != nil {
+			colour := colours.Colour(options.Colour.AtVec(v))
+			fmt.Fprintf(b, ", style=filled, fillcolor=%s", quote(colour))
+		}
+
+		for _, name := range names {
+			fmt.Fprintf(b, ", %s=%s", quote(name), quote(fmt.Sprint(options.Attributes[name].AtVec(v))))
+		}
+
+		fmt.Fprint(b, "];\n")
+	}
+
+	for _, e := range edges {
+		fmt.Fprintf(b, "\t%d %s %d", e.Row, connector, e.Column)
+		if options.Weights {
+			fmt.Fprintf(b, " [label=%s]", quote(fmt.Sprint(e.Value)))
+		}
+		fmt.Fprint(b, ";\n")
+	}
+
+	fmt.Fprint(b, "}\n")
+	return b.Flush()
+}
+
+// escape backslashes and double quotes, the only characters DOT escapes within a quoted string
+var escape = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// quote returns value as a DOT quoted string
+func quote(value string) string {
+	return `"` + escape.Replace(value) + `"`
+}
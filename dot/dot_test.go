@@ -0,0 +1,137 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package dot_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/dot"
+	"github.com/rossmerr/graphblas/edgelist"
+)
+
+func TestWrite(t *testing.T) {
+	m := graphblas.NewCSRMatrixFromArray([][]float64{
+		{0, 2, 0},
+		{2, 0, 3},
+		{0, 3, 0},
+	})
+	clusters := graphblas.NewDenseVectorFromArrayN([]float64{1, 1, 2})
+	labels := edgelist.NewLabelsFromArray([]string{"a", "b", "c"})
+	threshold := 3.0
+
+	tests := []struct {
+		name    string
+		options dot.Options[float64]
+		want    string
+	}{
+		{
+			name:    "Directed",
+			options: dot.Options[float64]{},
+			want: "digraph G {\n" +
+				"\t0 [label=\"0\"];\n" +
+				"\t1 [label=\"1\"];\n" +
+				"\t2 [label=\"2\"];\n" +
+				"\t0 -> 1;\n" +
+				"\t1 -> 0;\n" +
+				"\t1 -> 2;\n" +
+				"\t2 -> 1;\n" +
+				"}\n",
+		},
+		{
+			name:    "Undirected",
+			options: dot.Options[float64]{Labels: labels, Undirected: true, Weights: true, Colour: clusters},
+			want: "graph G {\n" +
+				"\t0 [label=\"a\", style=filled, fillcolor=\"#1f77b4\"];\n" +
+				"\t1 [label=\"b\", style=filled, fillcolor=\"#1f77b4\"];\n" +
+				"\t2 [label=\"c\", style=filled, fillcolor=\"#ff7f0e\"];\n" +
+				"\t0 -- 1 [label=\"2\"];\n" +
+				"\t1 -- 2 [label=\"3\"];\n" +
+				"}\n",
+		},
+		{
+			name: "Threshold",
+			options: dot.Options[float64]{
+				Undirected: true,
+				Threshold:  &threshold,
+				Attributes: map[string]graphblas.VectorLogial[float64]{"cluster": clusters},
+			},
+			want: "graph G {\n" +
+				"\t1 [label=\"1\", \"cluster\"=\"1\"];\n" +
+				"\t2 [label=\"2\", \"cluster\"=\"2\"];\n" +
+				"\t1 -- 2;\n" +
+				"}\n",
+		},
+		{
+			name:    "Limit",
+			options: dot.Options[float64]{Limit: 1},
+			want: "digraph G {\n" +
+				"\t0 [label=\"0\"];\n" +
+				"\t1 [label=\"1\"];\n" +
+				"\t0 -> 1;\n" +
+				"}\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			if err := dot.Write[float64](context.Background(), &b, m, tt.options); err != nil {
+				t.Fatalf("%+v Write error %+v", tt.name, err)
+			}
+
+			if b.String() != tt.want {
+				t.Errorf("%+v Write = %q, want %q", tt.name, b.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestWrite_ThresholdZero(t *testing.T) {
+	m := graphblas.NewCSRMatrixFromArray([][]float64{
+		{0, -2, 0},
+		{0, 0, 3},
+		{0, 0, 0},
+	})
+	threshold := 0.0
+
+	var b bytes.Buffer
+	if err := dot.Write[float64](context.Background(), &b, m, dot.Options[float64]{Threshold: &threshold}); err != nil {
+		t.Fatalf("Write error %+v", err)
+	}
+
+	want := "digraph G {\n" +
+		"\t1 [label=\"1\"];\n" +
+		"\t2 [label=\"2\"];\n" +
+		"\t1 -> 2;\n" +
+		"}\n"
+	if b.String() != want {
+		t.Errorf("Write = %q, want %q", b.String(), want)
+	}
+}
+
+func TestWrite_Escape(t *testing.T) {
+	m := graphblas.NewCSRMatrixFromArray([][]float64{
+		{0, 1},
+		{0, 0},
+	})
+	labels := edgelist.NewLabelsFromArray([]string{`a"b\c`, "café\tü"})
+
+	var b bytes.Buffer
+	if err := dot.Write[float64](context.Background(), &b, m, dot.Options[float64]{Labels: labels}); err != nil {
+		t.Fatalf("Write error %+v", err)
+	}
+
+	want := "digraph G {\n" +
+		"\t0 [label=\"a\\\"b\\\\c\"];\n" +
+		"\t1 [label=\"café\tü\"];\n" +
+		"\t0 -> 1;\n" +
+		"}\n"
+	if b.String() != want {
+		t.Errorf("Write = %q, want %q", b.String(), want)
+	}
+}
@@ -0,0 +1,264 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+// Package edgelist reads and writes graphs stored as "src dst weight" edge lists or CSV
+package edgelist
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+	"unsafe"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/binaryop"
+	"github.com/rossmerr/graphblas/constraints"
+)
+
+// Format of the matrix built by Read
+type Format int
+
+const (
+	// CSR compressed storage by rows
+	CSR Format = iota
+	// CSC compressed storage by columns
+	CSC
+)
+
+// Options controls how edges are read and written
+type Options[T constraints.Number] struct {
+	// Comma is the field delimiter, zero for whitespace separated edge lists
+	Comma rune
+
+	// Header the first line holds column names rather than an edge
+	Header bool
+
+	// Undirected each edge is stored in both directions, the writer only emits r <= c
+	Undirected bool
+
+	// SkipSelfLoops drops edges where the source and destination are the same vertex
+	SkipSelfLoops bool
+
+	// Combine folds duplicate edges, when nil the last edge read wins
+	Combine binaryop.BinaryOp[T]
+
+	// Format of the matrix returned by Read
+	Format Format
+}
+
+// Read parses an edge list where each line is "src dst [weight]", a missing weight reads as 1
+// vertex labels are assigned dense indices in order of first appearance
+func Read[T constraints.Number](ctx context.Context, r io.Reader, options Options[T]) (graphblas.Matrix[T], *Labels, error) {
+	next := fields(r, options.Comma)
+	labels := NewLabels()
+
+	rows := []int{}
+	cols := []int{}
+	values := []T{}
+
+	header := options.Header
+	for record := 1; ; record++ {
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		default:
+		}
+
+		field, err := next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("edgelist: record %d: %w", record, err)
+		}
+
+		if len(field) == 0 {
+			continue
+		}
+
+		if header {
+			header = false
+			continue
+		}
+
+		if len(field) < 2 || len(field) > 3 {
+			return nil, nil, fmt.Errorf("edgelist: record %d: expected 2 or 3 fields found %d", record, len(field))
+		}
+
+		weight := T(1)
+		if len(field) == 3 {
+			weight, err = Parse[T](field[2])
+			if err != nil {
+				return nil, nil, fmt.Errorf("edgelist: record %d: %w", record, err)
+			}
+		}
+
+		src := labels.Add(field[0])
+		dst := labels.Add(field[1])
+
+		if src == dst && options.SkipSelfLoops {
+			continue
+		}
+
+		rows = append(rows, src)
+		cols = append(cols, dst)
+		values = append(values, weight)
+
+		if options.Undirected && src != dst {
+			rows = append(rows, dst)
+			cols = append(cols, src)
+			values = append(values, weight)
+		}
+	}
+
+	n := labels.Len()
+	if options.Format == CSC {
+		return graphblas.NewCSCMatrixFromTuples(n, n, rows, cols, values, options.Combine), labels, nil
+	}
+
+	return graphblas.NewCSRMatrixFromTuples(n, n, rows, cols, values, options.Combine), labels, nil
+}
+
+// Write emits each non-zero element of s as "src dst weight", labels may be nil to write the indices, zero is no edge
+// in a matrix so an edge of weight zero is never written
+//
+// a label Read would give back differently returns an error, in a whitespace separated list a label that is empty,
+// holds whitespace or starts a line with '#' or '%', and in CSV a source label starting with '#'
+func Write[T constraints.Number](ctx context.Context, w io.Writer, s graphblas.MatrixLogical[T], labels *Labels, options Options[T]) error {
+	write, flush := records(w, options.Comma)
+
+	if options.Header {
+		if err := write([]string{"src", "dst", "weight"}); err != nil {
+			return err
+		}
+	}
+
+	for iterator := s.Enumerate(); iterator.HasNext(); {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		r, c, value := iterator.Next()
+		if graphblas.IsZero(value) {
+			continue
+		}
+
+		if r == c && options.SkipSelfLoops {
+			continue
+		}
+
+		if r > c && options.Undirected {
+			continue
+		}
+
+		src := labels.Label(r)
+		dst := labels.Label(c)
+
+		if err := readable(src, dst, options.Comma); err != nil {
+			return err
+		}
+
+		if err := write([]string{src, dst, fmt.Sprint(value)}); err != nil {
+			return err
+		}
+	}
+
+	return flush()
+}
+
+// records returns a func writing one record at a time and a func flushing them, whitespace separated records are
+// written as they are as the reader does not unquote them
+func records(w io.Writer, comma rune) (func([]string) error, func() error) {
+	if comma != 0 {
+		writer := csv.NewWriter(w)
+		writer.Comma = comma
+		return writer.Write, func() error {
+			writer.Flush()
+			return writer.Error()
+		}
+	}
+
+	writer := bufio.NewWriter(w)
+	return func(field []string) error {
+		_, err := writer.WriteString(strings.Join(field, " ") + "\n")
+		return err
+	}, writer.Flush
+}
+
+// readable checks the labels of an edge read back as the same labels
+func readable(src, dst string, comma rune) error {
+	if comma != 0 {
+		if strings.HasPrefix(src, "#") {
+			return fmt.Errorf("edgelist: label %q starts a comment", src)
+		}
+		return nil
+	}
+
+	for _, label := range []string{src, dst} {
+		if label == "" || strings.IndexFunc(label, unicode.IsSpace) >= 0 {
+			return fmt.Errorf("edgelist: label %q is empty or contains whitespace", label)
+		}
+	}
+
+	if strings.HasPrefix(src, "#") || strings.HasPrefix(src, "%") {
+		return fmt.Errorf("edgelist: label %q starts a comment", src)
+	}
+
+	return nil
+}
+
+// fields returns a func reading one record at a time, comments start with '#' or '%'
+func fields(r io.Reader, comma rune) func() ([]string, error) {
+	if comma != 0 {
+		reader := csv.NewReader(r)
+		reader.Comma = comma
+		reader.Comment = '#'
+		reader.FieldsPerRecord = -1
+		reader.TrimLeadingSpace = true
+		return reader.Read
+	}
+
+	scanner := bufio.NewScanner(r)
+	return func() ([]string, error) {
+		if !scanner.Scan() {
+			if err := scanner.Err(); err != nil {
+				return nil, err
+			}
+			return nil, io.EOF
+		}
+
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "#") || strings.HasPrefix(line, "%") {
+			return []string{}, nil
+		}
+
+		return strings.Fields(line), nil
+	}
+}
+
+// Parse converts the text to T using the integer or float parser matching T, a value outside the range of T
+// returns the parser's range error
+func Parse[T constraints.Number](s string) (T, error) {
+	s = strings.TrimSpace(s)
+	bits := int(8 * unsafe.Sizeof(graphblas.Zero[T]()))
+	switch any(graphblas.Zero[T]()).(type) {
+	case float32, float64:
+		v, err := strconv.ParseFloat(s, bits)
+		return T(v), err
+	case int, int8, int16, int32, int64:
+		v, err := strconv.ParseInt(s, 10, bits)
+		return T(v), err
+	default:
+		v, err := strconv.ParseUint(s, 10, bits)
+		return T(v), err
+	}
+}
@@ -0,0 +1,294 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package edgelist_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/binaryop"
+	"github.com/rossmerr/graphblas/constraints"
+	"github.com/rossmerr/graphblas/edgelist"
+)
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		options edgelist.Options[float64]
+		want    [][]float64
+	}{
+		{
+			name:    "Whitespace",
+			input:   "# comment\na b 2\nb c\n",
+			options: edgelist.Options[float64]{},
+			want: [][]float64{
+				{0, 2, 0},
+				{0, 0, 1},
+				{0, 0, 0},
+			},
+		},
+		{
+			name:    "CSV",
+			input:   "src,dst,weight\na,b,2\nb,c,3\n",
+			options: edgelist.Options[float64]{Comma: ',', Header: true, Format: edgelist.CSC},
+			want: [][]float64{
+				{0, 2, 0},
+				{0, 0, 3},
+				{0, 0, 0},
+			},
+		},
+		{
+			name:    "Undirected",
+			input:   "a b 2\nb c 3\n",
+			options: edgelist.Options[float64]{Undirected: true},
+			want: [][]float64{
+				{0, 2, 0},
+				{2, 0, 3},
+				{0, 3, 0},
+			},
+		},
+		{
+			name:    "SkipSelfLoops",
+			input:   "a a 5\na b 1\n",
+			options: edgelist.Options[float64]{SkipSelfLoops: true},
+			want: [][]float64{
+				{0, 1},
+				{0, 0},
+			},
+		},
+		{
+			name:    "Combine",
+			input:   "a b 1\na b 4\n",
+			options: edgelist.Options[float64]{Combine: binaryop.Addition[float64]()},
+			want: [][]float64{
+				{0, 5},
+				{0, 0},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, labels, err := edgelist.Read(context.Background(), strings.NewReader(tt.input), tt.options)
+			if err != nil {
+				t.Fatalf("%+v Read error %+v", tt.name, err)
+			}
+
+			want := graphblas.NewDenseMatrixFromArrayN(tt.want)
+			if !m.Equal(want) {
+				t.Errorf("%+v Read = %+v, want %+v", tt.name, m, want)
+			}
+
+			values := 0
+			for iterator := want.Enumerate(); iterator.HasNext(); {
+				if _, _, v := iterator.Next(); v != 0 {
+					values++
+				}
+			}
+			if m.Values() != values {
+				t.Errorf("%+v Values = %+v, want %+v", tt.name, m.Values(), values)
+			}
+
+			if i, _ := labels.Index("b"); labels.Label(i) != "b" {
+				t.Errorf("%+v Label = %+v, want %+v", tt.name, labels.Label(i), "b")
+			}
+		})
+	}
+}
+
+func TestRead_Error(t *testing.T) {
+	_, _, err := edgelist.Read(context.Background(), strings.NewReader("a b c d\n"), edgelist.Options[int]{})
+	if err == nil {
+		t.Errorf("Read expected an error for too many fields")
+	}
+
+	_, _, err = edgelist.Read(context.Background(), strings.NewReader("a b x\n"), edgelist.Options[int]{})
+	if err == nil {
+		t.Errorf("Read expected an error for an invalid weight")
+	}
+
+	_, _, err = edgelist.Read(context.Background(), strings.NewReader("x y 300\n"), edgelist.Options[int8]{})
+	if err == nil {
+		t.Errorf("Read expected an error for a weight out of range")
+	}
+}
+
+func TestParse_Range(t *testing.T) {
+	tests := []struct {
+		name  string
+		parse func(s string) error
+		value string
+		err   bool
+	}{
+		{name: "int8", parse: parse[int8], value: "127"},
+		{name: "int8 Overflow", parse: parse[int8], value: "300", err: true},
+		{name: "int8 Underflow", parse: parse[int8], value: "-129", err: true},
+		{name: "uint8", parse: parse[uint8], value: "255"},
+		{name: "uint8 Overflow", parse: parse[uint8], value: "256", err: true},
+		{name: "float32", parse: parse[float32], value: "3.5"},
+		{name: "float32 Overflow", parse: parse[float32], value: "1e39", err: true},
+		{name: "float64", parse: parse[float64], value: "1e39"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.parse(tt.value)
+			if tt.err && !errors.Is(err, strconv.ErrRange) {
+				t.Errorf("%+v Parse(%q) error = %+v, want %+v", tt.name, tt.value, err, strconv.ErrRange)
+			}
+			if !tt.err && err != nil {
+				t.Errorf("%+v Parse(%q) error %+v", tt.name, tt.value, err)
+			}
+		})
+	}
+}
+
+func parse[T constraints.Number](s string) error {
+	_, err := edgelist.Parse[T](s)
+	return err
+}
+
+func TestWrite(t *testing.T) {
+	labels := edgelist.NewLabelsFromArray([]string{"a", "b", "c"})
+	m := graphblas.NewCSRMatrixFromArray([][]int{
+		{1, 2, 0},
+		{2, 0, 3},
+		{0, 3, 0},
+	})
+
+	tests := []struct {
+		name    string
+		options edgelist.Options[int]
+		want    string
+	}{
+		{
+			name:    "Directed",
+			options: edgelist.Options[int]{},
+			want:    "a a 1\na b 2\nb a 2\nb c 3\nc b 3\n",
+		},
+		{
+			name:    "Undirected",
+			options: edgelist.Options[int]{Undirected: true, SkipSelfLoops: true, Comma: ',', Header: true},
+			want:    "src,dst,weight\na,b,2\nb,c,3\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			err := edgelist.Write[int](context.Background(), &b, m, labels, tt.options)
+			if err != nil {
+				t.Fatalf("%+v Write error %+v", tt.name, err)
+			}
+
+			if b.String() != tt.want {
+				t.Errorf("%+v Write = %q, want %q", tt.name, b.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestWrite_RoundTrip(t *testing.T) {
+	input := "x y 1.5\ny z 2\nz x 0.25\n"
+	options := edgelist.Options[float64]{}
+
+	m, labels, err := edgelist.Read(context.Background(), strings.NewReader(input), options)
+	if err != nil {
+		t.Fatalf("Read error %+v", err)
+	}
+
+	var b bytes.Buffer
+	if err := edgelist.Write[float64](context.Background(), &b, m, labels, options); err != nil {
+		t.Fatalf("Write error %+v", err)
+	}
+
+	if b.String() != input {
+		t.Errorf("Write = %q, want %q", b.String(), input)
+	}
+}
+
+func TestWrite_Labels(t *testing.T) {
+	m := graphblas.NewCSRMatrixFromArray([][]int{
+		{0, 1},
+		{0, 0},
+	})
+
+	tests := []struct {
+		name    string
+		labels  []string
+		options edgelist.Options[int]
+		err     bool
+	}{
+		{
+			name:   "Quote",
+			labels: []string{`a"b`, `"c"`},
+		},
+		{
+			name:   "Comment Target",
+			labels: []string{"a", "#b"},
+		},
+		{
+			name:   "Comment",
+			labels: []string{"#a", "b"},
+			err:    true,
+		},
+		{
+			name:   "Percent",
+			labels: []string{"%a", "b"},
+			err:    true,
+		},
+		{
+			name:   "Whitespace",
+			labels: []string{"a", "b\u00a0c"},
+			err:    true,
+		},
+		{
+			name:   "Empty",
+			labels: []string{"a", ""},
+			err:    true,
+		},
+		{
+			name:    "CSV",
+			labels:  []string{`a"b`, "c, d"},
+			options: edgelist.Options[int]{Comma: ','},
+		},
+		{
+			name:    "CSV Comment",
+			labels:  []string{"#a", "b"},
+			options: edgelist.Options[int]{Comma: ','},
+			err:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			err := edgelist.Write[int](context.Background(), &b, m, edgelist.NewLabelsFromArray(tt.labels), tt.options)
+			if tt.err {
+				if err == nil {
+					t.Errorf("%+v Write expected an error", tt.name)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%+v Write error %+v", tt.name, err)
+			}
+
+			got, labels, err := edgelist.Read(context.Background(), &b, tt.options)
+			if err != nil {
+				t.Fatalf("%+v Read error %+v", tt.name, err)
+			}
+
+			src, _ := labels.Index(tt.labels[0])
+			dst, _ := labels.Index(tt.labels[1])
+			if labels.Len() != 2 || got.At(src, dst) != 1 {
+				t.Errorf("%+v Read = %+v, want %q → %q", tt.name, labels, tt.labels[0], tt.labels[1])
+			}
+		})
+	}
+}
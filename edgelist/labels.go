@@ -0,0 +1,70 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package edgelist
+
+import (
+	"log"
+	"strconv"
+)
+
+// Labels is a dictionary mapping vertex labels to dense integer indices
+type Labels struct {
+	index  map[string]int
+	labels []string
+}
+
+// NewLabels returns a Labels
+func NewLabels() *Labels {
+	return &Labels{index: make(map[string]int)}
+}
+
+// NewLabelsFromArray returns a Labels where the i-th label maps to index i
+func NewLabelsFromArray(labels []string) *Labels {
+	s := NewLabels()
+	for _, label := range labels {
+		if _, found := s.index[label]; found {
+			log.Panicf("Label %q is a duplicate", label)
+		}
+		s.Add(label)
+	}
+	return s
+}
+
+// Add returns the index of the label, assigning the next free index when the label is new
+func (s *Labels) Add(label string) int {
+	if i, found := s.index[label]; found {
+		return i
+	}
+
+	i := len(s.labels)
+	s.index[label] = i
+	s.labels = append(s.labels, label)
+	return i
+}
+
+// Index returns the index of the label
+func (s *Labels) Index(label string) (int, bool) {
+	i, found := s.index[label]
+	return i, found
+}
+
+// Label returns the label at i-th, falling back to the index itself when s is nil
+func (s *Labels) Label(i int) string {
+	if s == nil {
+		return strconv.Itoa(i)
+	}
+
+	if i < 0 || i >= len(s.labels) {
+		log.Panicf("Index '%+v' is invalid", i)
+	}
+
+	return s.labels[i]
+}
+
+// Len the number of labels
+func (s *Labels) Len() int {
+	return len(s.labels)
+}
@@ -0,0 +1,187 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package graphblas
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/rossmerr/graphblas/constraints"
+)
+
+const (
+	// formatMaxRows rows beyond are elided from the middle of a grid
+	formatMaxRows = 16
+	// formatMaxColumns columns beyond are elided from the middle of a grid or vector
+	formatMaxColumns = 16
+	// formatMaxValues non-zero values beyond are elided from the end of a sparse listing
+	formatMaxValues = 32
+)
+
+type layout int
+
+const (
+	layoutGrid layout = iota
+	layoutTriples
+	layoutVector
+	layoutPairs
+)
+
+// format writes s for the fmt.Formatter implementations
+//
+// %v prints dense matrices as aligned grids, sparse matrices as (r, c, v) triples unless they fit within a grid
+// of formatMaxRows by formatMaxColumns and vectors on one line, %+v adds a header with the type, size and number of
+// values, the width, precision and any other verb such as %6.2f or %c are applied to each element
+func format[T constraints.Type](f fmt.State, verb rune, s MatrixLogical[T], l layout) {
+	if l == layoutTriples && s.Rows() <= formatMaxRows && s.Columns() <= formatMaxColumns {
+		l = layoutGrid
+	}
+
+	element := "%"
+	if width, ok := f.Width(); ok {
+		element += strconv.Itoa(width)
+	}
+	if precision, ok := f.Precision(); ok {
+		element += "." + strconv.Itoa(precision)
+	}
+	if verb == 's' {
+		verb = 'v'
+	}
+	element += string(verb)
+
+	value := func(v T) string {
+		return fmt.Sprintf(element, v)
+	}
+
+	var b strings.Builder
+	if f.Flag('+') {
+		fmt.Fprintf(&b, "%s %dx%d, %d values\n", reflect.TypeOf(s).Elem().Name(), s.Rows(), s.Columns(), s.Values())
+	}
+
+	switch l {
+	case layoutGrid:
+		formatGrid(&b, s, value)
+	case layoutTriples:
+		formatTriples(&b, s, value, false)
+	case layoutVector:
+		formatVector(&b, s, value)
+	case layoutPairs:
+		formatTriples(&b, s, value, true)
+	}
+
+	f.Write([]byte(b.String()))
+}
+
+// visible returns the indices shown out of n, -1 marks the elided middle
+func visible(n, max int) []int {
+	indices := []int{}
+	if n <= max {
+		for i := 0; i < n; i++ {
+			indices = append(indices, i)
+		}
+		return indices
+	}
+
+	for i := 0; i < max/2; i++ {
+		indices = append(indices, i)
+	}
+	indices = append(indices, -1)
+	for i := n - max/2; i < n; i++ {
+		indices = append(indices, i)
+	}
+
+	return indices
+}
+
+func formatGrid[T constraints.Type](b *strings.Builder, s MatrixLogical[T], value func(T) string) {
+	rows := visible(s.Rows(), formatMaxRows)
+	columns := visible(s.Columns(), formatMaxColumns)
+
+	cells := make([][]string, len(rows))
+	widths := make([]int, len(columns))
+	for i, r := range rows {
+		cells[i] = make([]string, len(columns))
+		for j, c := range columns {
+			switch {
+			case r == -1 && c == -1:
+				cells[i][j] = "⋱"
+			case r == -1:
+				cells[i][j] = "⋮"
+			case c == -1:
+				cells[i][j] = "…"
+			default:
+				cells[i][j] = value(s.At(r, c))
+			}
+
+			if w := len([]rune(cells[i][j])); w > widths[j] {
+				widths[j] = w
+			}
+		}
+	}
+
+	for i := range cells {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString("[")
+		for j, cell := range cells[i] {
+			if j > 0 {
+				b.WriteString(" ")
+			}
+			b.WriteString(strings.Repeat(" ", widths[j]-len([]rune(cell))))
+			b.WriteString(cell)
+		}
+		b.WriteString("]")
+	}
+}
+
+func formatVector[T constraints.Type](b *strings.Builder, s MatrixLogical[T], value func(T) string) {
+	b.WriteString("[")
+	for i, r := range visible(s.Rows(), formatMaxColumns) {
+		if i > 0 {
+			b.WriteString(" ")
+		}
+		if r == -1 {
+			b.WriteString("…")
+		} else {
+			b.WriteString(value(s.At(r, 0)))
+		}
+	}
+	b.WriteString("]")
+}
+
+// formatTriples lists the non-zero elements in enumeration order, pairs drops the column for vectors
+func formatTriples[T constraints.Type](b *strings.Builder, s MatrixLogical[T], value func(T) string, pairs bool) {
+	count := 0
+	for iterator := s.Enumerate(); iterator.HasNext(); {
+		r, c, v := iterator.Next()
+		if IsZero(v) {
+			continue
+		}
+
+		if count == formatMaxValues {
+			fmt.Fprintf(b, "\n… %d more", s.Values()-count)
+			return
+		}
+
+		if count > 0 {
+			b.WriteString("\n")
+		}
+
+		if pairs {
+			fmt.Fprintf(b, "(%d, %s)", r, value(v))
+		} else {
+			fmt.Fprintf(b, "(%d, %d, %s)", r, c, value(v))
+		}
+		count++
+	}
+
+	if count == 0 {
+		b.WriteString("()")
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package graphblas_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/rossmerr/graphblas"
+)
+
+func TestMatrix_Format(t *testing.T) {
+	array := [][]float64{
+		{0, 2.5, 0},
+		{1, 0, -3},
+	}
+
+	tests := []struct {
+		name   string
+		format string
+		s      interface{}
+		want   string
+	}{
+		{
+			name:   "DenseMatrix",
+			format: "%v",
+			s:      graphblas.NewDenseMatrixFromArrayN(array),
+			want:   "[0 2.5  0]\n[1   0 -3]",
+		},
+		{
+			name:   "DenseMatrix Precision",
+			format: "%+.1f",
+			s:      graphblas.NewDenseMatrixFromArrayN(array),
+			want:   "DenseMatrixNumber[float64] 2x3, 6 values\n[0.0 2.5  0.0]\n[1.0 0.0 -3.0]",
+		},
+		{
+			name:   "CSRMatrix",
+			format: "%v",
+			s:      graphblas.NewCSRMatrixFromArray(array),
+			want:   "[0 2.5  0]\n[1   0 -3]",
+		},
+		{
+			name:   "CSCMatrix",
+			format: "%+v",
+			s:      graphblas.NewCSCMatrixFromArray(array),
+			want:   "CSCMatrix[float64] 2x3, 3 values\n[0 2.5  0]\n[1   0 -3]",
+		},
+		{
+			name:   "DenseVector",
+			format: "%.2f",
+			s:      graphblas.NewDenseVectorFromArrayN([]float64{1, 0.5}),
+			want:   "[1.00 0.50]",
+		},
+		{
+			name:   "SparseVector",
+			format: "%v",
+			s:      graphblas.NewSparseVectorFromArray([]float64{0, 4, 0, 5}),
+			want:   "(1, 4)\n(3, 5)",
+		},
+		{
+			name:   "MatrixRune",
+			format: "%c",
+			s:      graphblas.NewDenseMatrixFromArray([][]rune{{'a', 'b'}, {'c', 'd'}}),
+			want:   "[a b]\n[c d]",
+		},
+		{
+			name:   "MutexMatrix",
+			format: "%v",
+			s:      graphblas.NewMutexMatrix[float64](graphblas.NewCSRMatrixFromArray(array)),
+			want:   "[0 2.5  0]\n[1   0 -3]",
+		},
+		{
+			name:   "CSRMatrix Width",
+			format: "%5.1f",
+			s:      graphblas.NewCSRMatrixFromArray(array),
+			want:   "[  0.0   2.5   0.0]\n[  1.0   0.0  -3.0]",
+		},
+		{
+			name:   "CSRMatrix Triples",
+			format: "%v",
+			s:      graphblas.NewCSRMatrixFromTuples(2, 20, []int{0, 1}, []int{1, 19}, []float64{2.5, -3}, nil),
+			want:   "(0, 1, 2.5)\n(1, 19, -3)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := fmt.Sprintf(tt.format, tt.s)
+			if got != tt.want {
+				t.Errorf("%+v Format = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatrix_Format_Elision(t *testing.T) {
+	dense := graphblas.NewDenseMatrixN[int](20, 20)
+	sparse := graphblas.NewCSRMatrix[int](100, 100)
+	for i := 0; i < 40; i++ {
+		dense.Set(i%20, i%20, 1)
+		sparse.Set(i, i, 1)
+	}
+
+	grid := strings.Split(fmt.Sprint(dense), "\n")
+	if len(grid) != 17 || !strings.Contains(grid[8], "⋱") || !strings.Contains(grid[0], "…") {
+		t.Errorf("Format grid = %q, want 17 rows with the middle elided", grid)
+	}
+
+	triples := strings.Split(fmt.Sprint(sparse), "\n")
+	if len(triples) != 33 || triples[32] != "… 8 more" {
+		t.Errorf("Format triples = %q, want 32 triples then … 8 more", triples)
+	}
+}
@@ -0,0 +1,313 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+// Package graphml reads and writes matrices as GraphML graphs
+package graphml
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/constraints"
+	"github.com/rossmerr/graphblas/edgelist"
+	"github.com/rossmerr/graphblas/internal/render"
+)
+
+const namespace = "http://graphml.graphdrawing.org/xmlns"
+
+// Options controls what is written for each vertex and edge
+type Options[T constraints.Number] struct {
+	// Labels the vertex labels used as node ids, when nil the indices are used
+	Labels *edgelist.Labels
+
+	// Undirected sets edgedefault to undirected and only emits r <= c
+	Undirected bool
+
+	// Weights writes the edge weight as the "weight" data key
+	Weights bool
+
+	// Colour writes a "color" data key on each vertex picked by its value, e.g. a cluster ID, Write returns an
+	// error when Attributes also has a "color"
+	Colour graphblas.VectorLogial[T]
+
+	// Attributes written on each vertex as data keys by name
+	Attributes map[string]graphblas.VectorLogial[T]
+
+	// Threshold edges with a weight below are skipped, nil keeps every edge
+	Threshold *T
+
+	// Limit the maximum number of edges written, zero for no limit
+	Limit int
+}
+
+// Graph is a GraphML document read into a matrix
+type Graph[T constraints.Number] struct {
+	// Matrix the adjacency matrix, undirected edges are stored in both directions
+	Matrix graphblas.Matrix[T]
+
+	// Labels maps the node ids to the matrix indices
+	Labels *edgelist.Labels
+
+	// Attributes the numeric node data keys by attr.name
+	Attributes map[string]graphblas.Vector[T]
+}
+
+type document struct {
+	XMLName xml.Name `xml:"graphml"`
+	XMLNS   string   `xml:"xmlns,attr,omitempty"`
+	Keys    []key    `xml:"key"`
+	Graph   graph    `xml:"graph"`
+}
+
+type key struct {
+	ID      string  `xml:"id,attr"`
+	For     string  `xml:"for,attr"`
+	Name    string  `xml:"attr.name,attr"`
+	Type    string  `xml:"attr.type,attr"`
+	Default *string `xml:"default,omitempty"`
+}
+
+type graph struct {
+	ID          string `xml:"id,attr"`
+	EdgeDefault string `xml:"edgedefault,attr"`
+	Nodes       []node `xml:"node"`
+	Edges       []link `xml:"edge"`
+}
+
+type node struct {
+	ID   string `xml:"id,attr"`
+	Data []data `xml:"data"`
+}
+
+type link struct {
+	Source   string `xml:"source,attr"`
+	Target   string `xml:"target,attr"`
+	Directed string `xml:"directed,attr,omitempty"`
+	Data     []data `xml:"data"`
+}
+
+type data struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// Write emits s as a GraphML document, when Threshold or Limit drop edges only the vertices they touch are written
+func Write[T constraints.Number](ctx context.Context, w io.Writer, s graphblas.MatrixLogical[T], options Options[T]) error {
+	vertices, edges, err := render.Collect(ctx, s, options.Undirected, options.Threshold, options.Limit)
+	if err != nil {
+		return err
+	}
+
+	doc := document{XMLNS: namespace}
+	doc.Graph.ID = "G"
+	doc.Graph.EdgeDefault = "directed"
+	if options.Undirected {
+		doc.Graph.EdgeDefault = "undirected"
+	}
+
+	if _, found := options.Attributes["color"]; found && options.Colour != nil {
+		return fmt.Errorf("graphml: attribute %q collides with the Colour key", "color")
+	}
+
+	names := make([]string, 0, len(options.Attributes))
+	for name := range options.Attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for i, name := range names {
+		doc.Keys = append(doc.Keys, key{ID: "d" + strconv.Itoa(i), For: "node", Name: name, Type: attrType[T]()})
+	}
+
+	if options.Colour != nil {
+		doc.Keys = append(doc.Keys, key{ID: "color", For: "node", Name: "color", Type: "string"})
+	}
+
+	if options.Weights {
+		doc.Keys = append(doc.Keys, key{ID: "weight", For: "edge", Name: "weight", Type: attrType[T]()})
+	}
+
+	colours := render.NewColours[T]()
+
+	for _, v := range vertices {
+		n := node{ID: options.Labels.Label(v)}
+
+		for i, name := range names {
+			n.Data = append(n.Data, data{Key: "d" + strconv.Itoa(i), Value: fmt.Sprint(options.Attributes[name].AtVec(v))})
+		}
+
+		if options.Colour != nil {
+			colour := colours.Colour(options.Colour.AtVec(v))
+			n.Data = append(n.Data, data{Key: "color", Value: colour})
+		}
+
+		doc.Graph.Nodes = append(doc.Graph.Nodes, n)
+	}
+
+	for _, e := range edges {
+		l := link{Source: options.Labels.Label(e.Row), Target: options.Labels.Label(e.Column)}
+		if options.Weights {
+			l.Data = append(l.Data, data{Key: "weight", Value: fmt.Sprint(e.Value)})
+		}
+		doc.Graph.Edges = append(doc.Graph.Edges, l)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "\n")
+	return err
+}
+
+// Read parses a GraphML document, the edge weight is read from the edge data key named "weight" and defaults to
+// the key's <default> or else 1, numeric node data keys are returned as attribute vectors filled with the key's
+// <default> where a node has no data, a node id declared twice returns an error
+func Read[T constraints.Number](ctx context.Context, r io.Reader) (*Graph[T], error) {
+	doc := document{}
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("graphml: %w", err)
+	}
+
+	weightKey := ""
+	defaultWeight := T(1)
+	nodeKeys := map[string]string{}
+	defaults := map[string]T{}
+	for _, k := range doc.Keys {
+		switch {
+		case k.For == "edge" && k.Name == "weight":
+			weightKey = k.ID
+			if k.Default != nil {
+				value, err := edgelist.Parse[T](*k.Default)
+				if err != nil {
+					return nil, fmt.Errorf("graphml: key %s default: %w", k.ID, err)
+				}
+				defaultWeight = value
+			}
+		case k.For == "node" && numeric(k.Type):
+			nodeKeys[k.ID] = k.Name
+			if k.Default != nil {
+				value, err := edgelist.Parse[T](*k.Default)
+				if err != nil {
+					return nil, fmt.Errorf("graphml: key %s default: %w", k.ID, err)
+				}
+				defaults[k.ID] = value
+			}
+		}
+	}
+
+	labels := edgelist.NewLabels()
+	for _, n := range doc.Graph.Nodes {
+		if _, found := labels.Index(n.ID); found {
+			return nil, fmt.Errorf("graphml: node %s is declared twice", n.ID)
+		}
+		labels.Add(n.ID)
+	}
+
+	rows := []int{}
+	cols := []int{}
+	values := []T{}
+
+	for _, e := range doc.Graph.Edges {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		weight := defaultWeight
+		for _, d := range e.Data {
+			if d.Key == weightKey {
+				value, err := edgelist.Parse[T](d.Value)
+				if err != nil {
+					return nil, fmt.Errorf("graphml: edge %s %s: %w", e.Source, e.Target, err)
+				}
+				weight = value
+			}
+		}
+
+		src := labels.Add(e.Source)
+		dst := labels.Add(e.Target)
+
+		rows = append(rows, src)
+		cols = append(cols, dst)
+		values = append(values, weight)
+
+		undirected := e.Directed == "false" || (e.Directed == "" && doc.Graph.EdgeDefault == "undirected")
+		if undirected && src != dst {
+			rows = append(rows, dst)
+			cols = append(cols, src)
+			values = append(values, weight)
+		}
+	}
+
+	n := labels.Len()
+	g := &Graph[T]{
+		Matrix:     graphblas.NewCSRMatrixFromTuples(n, n, rows, cols, values, nil),
+		Labels:     labels,
+		Attributes: map[string]graphblas.Vector[T]{},
+	}
+
+	for id, name := range nodeKeys {
+		attribute := graphblas.NewDenseVectorN[T](n)
+		if value, found := defaults[id]; found {
+			for i := 0; i < n; i++ {
+				attribute.SetVec(i, value)
+			}
+		}
+		g.Attributes[name] = attribute
+	}
+
+	for _, nd := range doc.Graph.Nodes {
+		i, _ := labels.Index(nd.ID)
+		for _, d := range nd.Data {
+			name, found := nodeKeys[d.Key]
+			if !found {
+				continue
+			}
+
+			value, err := edgelist.Parse[T](d.Value)
+			if err != nil {
+				return nil, fmt.Errorf("graphml: node %s: %w", nd.ID, err)
+			}
+			g.Attributes[name].SetVec(i, value)
+		}
+	}
+
+	return g, nil
+}
+
+// attrType the GraphML attr.type matching T
+func attrType[T constraints.Number]() string {
+	switch any(graphblas.Zero[T]()).(type) {
+	case float32:
+		return "float"
+	case float64:
+		return "double"
+	case int8, int16, int32, uint8, uint16:
+		return "int"
+	default:
+		return "long"
+	}
+}
+
+func numeric(t string) bool {
+	switch t {
+	case "int", "long", "float", "double":
+		return true
+	}
+	return false
+}
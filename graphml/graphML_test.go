@@ -0,0 +1,181 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package graphml_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/edgelist"
+	"github.com/rossmerr/graphblas/graphml"
+)
+
+func TestRead(t *testing.T) {
+	input := `<?xml version="1.0" encoding="UTF-8"?>
+<graphml xmlns="http://graphml.graphdrawing.org/xmlns">
+  <key id="w" for="edge" attr.name="weight" attr.type="double"/>
+  <key id="m" for="node" attr.name="modularity_class" attr.type="int"/>
+  <key id="l" for="node" attr.name="label" attr.type="string"/>
+  <graph id="G" edgedefault="undirected">
+    <node id="n0"><data key="m">0</data><data key="l">zero</data></node>
+    <node id="n1"><data key="m">1</data></node>
+    <node id="n2"><data key="m">1</data></node>
+    <edge source="n0" target="n1"><data key="w">2.5</data></edge>
+    <edge source="n1" target="n2" directed="true"/>
+  </graph>
+</graphml>`
+
+	g, err := graphml.Read[float64](context.Background(), strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Read error %+v", err)
+	}
+
+	want := graphblas.NewDenseMatrixFromArrayN([][]float64{
+		{0, 2.5, 0},
+		{2.5, 0, 1},
+		{0, 0, 0},
+	})
+	if !g.Matrix.Equal(want) || g.Matrix.Values() != 3 {
+		t.Errorf("Read = %+v, want %+v", g.Matrix, want)
+	}
+
+	if i, _ := g.Labels.Index("n2"); i != 2 {
+		t.Errorf("Index = %+v, want %+v", i, 2)
+	}
+
+	classes, found := g.Attributes["modularity_class"]
+	if !found {
+		t.Fatalf("Attributes missing modularity_class")
+	}
+
+	if classes.AtVec(2) != 1 {
+		t.Errorf("AtVec(%+v) = %+v, want %+v", 2, classes.AtVec(2), 1)
+	}
+
+	if _, found := g.Attributes["label"]; found {
+		t.Errorf("Attributes should skip string keys")
+	}
+}
+
+func TestWrite_RoundTrip(t *testing.T) {
+	m := graphblas.NewCSRMatrixFromArray([][]int{
+		{0, 2, 0},
+		{0, 0, 3},
+		{4, 0, 0},
+	})
+	clusters := graphblas.NewDenseVectorFromArrayN([]int{7, 7, 8})
+	labels := edgelist.NewLabelsFromArray([]string{"a", "b", "c"})
+
+	options := graphml.Options[int]{
+		Labels:     labels,
+		Weights:    true,
+		Colour:     clusters,
+		Attributes: map[string]graphblas.VectorLogial[int]{"cluster": clusters},
+	}
+
+	var b bytes.Buffer
+	if err := graphml.Write[int](context.Background(), &b, m, options); err != nil {
+		t.Fatalf("Write error %+v", err)
+	}
+
+	g, err := graphml.Read[int](context.Background(), &b)
+	if err != nil {
+		t.Fatalf("Read error %+v", err)
+	}
+
+	if !g.Matrix.Equal(m) || g.Matrix.Values() != m.Values() {
+		t.Errorf("Read = %+v, want %+v", g.Matrix, m)
+	}
+
+	if !g.Attributes["cluster"].Equal(clusters) {
+		t.Errorf("Attributes = %+v, want %+v", g.Attributes["cluster"], clusters)
+	}
+
+	if g.Labels.Label(1) != "b" {
+		t.Errorf("Label = %+v, want %+v", g.Labels.Label(1), "b")
+	}
+}
+
+func TestRead_Defaults(t *testing.T) {
+	input := `<?xml version="1.0" encoding="UTF-8"?>
+<graphml xmlns="http://graphml.graphdrawing.org/xmlns">
+  <key id="w" for="edge" attr.name="weight" attr.type="double"><default>4</default></key>
+  <key id="m" for="node" attr.name="class" attr.type="int"><default>9</default></key>
+  <graph id="G" edgedefault="directed">
+    <node id="n0"><data key="m">1</data></node>
+    <node id="n1"/>
+    <edge source="n0" target="n1"/>
+    <edge source="n1" target="n0"><data key="w">2</data></edge>
+  </graph>
+</graphml>`
+
+	g, err := graphml.Read[float64](context.Background(), strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Read error %+v", err)
+	}
+
+	want := graphblas.NewDenseMatrixFromArrayN([][]float64{
+		{0, 4},
+		{2, 0},
+	})
+	if !g.Matrix.Equal(want) {
+		t.Errorf("Read = %+v, want %+v", g.Matrix, want)
+	}
+
+	classes := g.Attributes["class"]
+	if classes.AtVec(0) != 1 || classes.AtVec(1) != 9 {
+		t.Errorf("Attributes = %+v, want [1 9]", classes)
+	}
+}
+
+func TestRead_DuplicateNode(t *testing.T) {
+	input := `<graphml xmlns="http://graphml.graphdrawing.org/xmlns">
+  <graph id="G" edgedefault="directed">
+    <node id="n0"/>
+    <node id="n0"/>
+  </graph>
+</graphml>`
+
+	if _, err := graphml.Read[float64](context.Background(), strings.NewReader(input)); err == nil {
+		t.Errorf("Read expected a duplicate node error")
+	}
+}
+
+func TestRead_Range(t *testing.T) {
+	doc := `<graphml>
+  <key id="w" for="edge" attr.name="weight" attr.type="int"/>
+  <graph edgedefault="directed">
+    <node id="a"/>
+    <node id="b"/>
+    <edge source="a" target="b"><data key="w">300</data></edge>
+  </graph>
+</graphml>`
+
+	if _, err := graphml.Read[int8](context.Background(), strings.NewReader(doc)); err == nil {
+		t.Errorf("Read expected an error for a weight out of range")
+	}
+}
+
+func TestWrite_Colour(t *testing.T) {
+	m := graphblas.NewCSRMatrixFromArray([][]int{
+		{0, 1},
+		{0, 0},
+	})
+	clusters := graphblas.NewDenseVectorFromArrayN([]int{1, 2})
+
+	options := graphml.Options[int]{
+		Colour:     clusters,
+		Attributes: map[string]graphblas.VectorLogial[int]{"color": clusters},
+	}
+
+	var b bytes.Buffer
+	if err := graphml.Write[int](context.Background(), &b, m, options); err == nil {
+		t.Errorf("Write expected an error for an attribute named color")
+	}
+}
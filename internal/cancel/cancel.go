@@ -0,0 +1,98 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+// Package cancel provides a context cancelled after a set number of checks, so tests can cancel an algorithm at
+// each point it looks at its context in turn
+package cancel
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Context is cancelled once Done or Err has been called more than a set number of times
+type Context struct {
+	mutex  sync.Mutex
+	after  int
+	checks int
+	done   chan struct{}
+}
+
+// After returns a context cancelled on its n+1th check, After(0) is cancelled from the first
+func After(n int) *Context {
+	return &Context{after: n, done: make(chan struct{})}
+}
+
+// check counts a check and closes done once the count passes after
+func (s *Context) check() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.checks++
+	if s.checks == s.after+1 {
+		close(s.done)
+	}
+	return s.checks > s.after
+}
+
+// Cancelled whether the context has been cancelled
+func (s *Context) Cancelled() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.checks > s.after
+}
+
+// Deadline the context has no deadline
+func (s *Context) Deadline() (deadline time.Time, ok bool) {
+	return time.Time{}, false
+}
+
+// Done returns a closed channel once the context is cancelled, otherwise nil which is never ready
+func (s *Context) Done() <-chan struct{} {
+	if s.check() {
+		return s.done
+	}
+	return nil
+}
+
+// Err returns context.Canceled once the context is cancelled
+func (s *Context) Err() error {
+	if s.check() {
+		return context.Canceled
+	}
+	return nil
+}
+
+// Value the context holds no values
+func (s *Context) Value(key any) any {
+	return nil
+}
+
+// Every runs f with a context cancelled at each of its checks in turn until a run finishes without being
+// cancelled, returns an error for the first cancelled run that returns no error or takes longer than timeout
+func Every(timeout time.Duration, f func(ctx context.Context) error) error {
+	for n := 0; ; n++ {
+		ctx := After(n)
+		result := make(chan error, 1)
+		go func() {
+			result <- f(ctx)
+		}()
+
+		select {
+		case err := <-result:
+			if !ctx.Cancelled() {
+				return nil
+			}
+			if err == nil {
+				return fmt.Errorf("cancelled at check %d returned no error", n)
+			}
+		case <-time.After(timeout):
+			return fmt.Errorf("cancelled at check %d did not return within %v", n, timeout)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+// Package partition numbers the groups a labelling of the vertices splits them into, shared by the components and
+// clustering packages
+package partition
+
+import (
+	"github.com/rossmerr/graphblas"
+)
+
+// Relabel numbers the distinct labels from 0 in order of their first vertex
+func Relabel(labels []int) graphblas.Vector[int] {
+	ids := map[int]int{}
+	result := graphblas.NewDenseVectorN[int](len(labels))
+	for v, label := range labels {
+		id, ok := ids[label]
+		if !ok {
+			id = len(ids)
+			ids[label] = id
+		}
+		result.SetVec(v, id)
+	}
+
+	return result
+}
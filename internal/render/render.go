@@ -0,0 +1,104 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+// Package render collects the vertices and edges of a matrix and picks their colours for the dot and graphml
+// writers
+package render
+
+import (
+	"context"
+	"sort"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/constraints"
+)
+
+// Palette the colours picked by Colours, values beyond its length wrap around
+var Palette = []string{
+	"#1f77b4", "#ff7f0e", "#2ca02c", "#d62728", "#9467bd",
+	"#8c564b", "#e377c2", "#7f7f7f", "#bcbd22", "#17becf",
+}
+
+// Colours picks the next colour of Palette for each value the first time it is seen, e.g. a cluster ID
+type Colours[T constraints.Number] struct {
+	picked map[T]string
+}
+
+// NewColours returns a Colours
+func NewColours[T constraints.Number]() *Colours[T] {
+	return &Colours[T]{picked: map[T]string{}}
+}
+
+// Colour returns the colour of the value
+func (s *Colours[T]) Colour(value T) string {
+	colour, found := s.picked[value]
+	if !found {
+		colour = Palette[len(s.picked)%len(Palette)]
+		s.picked[value] = colour
+	}
+	return colour
+}
+
+// Edge a non-zero element of a matrix written as the edge Row → Column
+type Edge[T constraints.Number] struct {
+	Row, Column int
+	Value       T
+}
+
+// Collect the edges of s to write and the sorted vertices they touch, all vertices when no edge was dropped
+//
+// undirected only collects r <= c, edges with a weight below threshold are dropped unless it is nil and at most
+// limit edges are collected unless it is zero
+func Collect[T constraints.Number](ctx context.Context, s graphblas.MatrixLogical[T], undirected bool, threshold *T, limit int) ([]int, []Edge[T], error) {
+	edges := []Edge[T]{}
+	dropped := false
+
+	for iterator := s.Enumerate(); iterator.HasNext(); {
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		default:
+		}
+
+		r, c, value := iterator.Next()
+		if graphblas.IsZero(value) || (undirected && r > c) {
+			continue
+		}
+
+		if threshold != nil && value < *threshold {
+			dropped = true
+			continue
+		}
+
+		if limit > 0 && len(edges) == limit {
+			dropped = true
+			break
+		}
+
+		edges = append(edges, Edge[T]{Row: r, Column: c, Value: value})
+	}
+
+	if !dropped {
+		vertices := make([]int, s.Rows())
+		for i := range vertices {
+			vertices[i] = i
+		}
+		return vertices, edges, nil
+	}
+
+	seen := map[int]bool{}
+	vertices := []int{}
+	for _, e := range edges {
+		for _, v := range []int{e.Row, e.Column} {
+			if !seen[v] {
+				seen[v] = true
+				vertices = append(vertices, v)
+			}
+		}
+	}
+	sort.Ints(vertices)
+
+	return vertices, edges, nil
+}
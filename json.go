@@ -0,0 +1,191 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package graphblas
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/rossmerr/graphblas/constraints"
+)
+
+// JSON formats written to the "format" field
+const (
+	FormatCSR          = "csr"
+	FormatCSC          = "csc"
+	FormatDense        = "dense"
+	FormatSparseVector = "sparse-vector"
+	FormatDenseVector  = "dense-vector"
+)
+
+// jsonMatrix the JSON representation shared by all matrices and vectors
+//
+// compressed formats store the row (csr) or column (csc) start offsets in pointers and the
+// minor index of each value in indices, sparse vectors store the index of each value in indices
+// and dense formats store every value row by row
+type jsonMatrix[T constraints.Type] struct {
+	Rows     int    `json:"rows"`
+	Cols     int    `json:"cols"`
+	Format   string `json:"format"`
+	Type     string `json:"type"`
+	Pointers []int  `json:"pointers,omitempty"`
+	Indices  []int  `json:"indices,omitempty"`
+	Values   []T    `json:"values"`
+}
+
+func typeName[T constraints.Type]() string {
+	return fmt.Sprintf("%T", Zero[T]())
+}
+
+func newJSONMatrix[T constraints.Type](r, c int, format string) *jsonMatrix[T] {
+	return &jsonMatrix[T]{
+		Rows:   r,
+		Cols:   c,
+		Format: format,
+		Type:   typeName[T](),
+		Values: []T{},
+	}
+}
+
+// unmarshalJSONMatrix decodes data and checks the format, type and shape
+func unmarshalJSONMatrix[T constraints.Type](data []byte, formats ...string) (*jsonMatrix[T], error) {
+	s := &jsonMatrix[T]{}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, err
+	}
+
+	found := false
+	for _, format := range formats {
+		found = found || s.Format == format
+	}
+	if !found {
+		return nil, fmt.Errorf("graphblas: format %q is not one of %q", s.Format, formats)
+	}
+
+	if s.Type != typeName[T]() {
+		return nil, fmt.Errorf("graphblas: type %q does not match %q", s.Type, typeName[T]())
+	}
+
+	if s.Rows < 0 || s.Cols < 0 {
+		return nil, fmt.Errorf("graphblas: size %+v, %+v is invalid", s.Rows, s.Cols)
+	}
+
+	switch s.Format {
+	case FormatCSR:
+		return s, s.validateCompressed(s.Rows, s.Cols)
+	case FormatCSC:
+		return s, s.validateCompressed(s.Cols, s.Rows)
+	case FormatSparseVector:
+		if s.Cols != 1 {
+			return nil, fmt.Errorf("graphblas: vector columns %+v is invalid", s.Cols)
+		}
+		return s, s.validateIndices(0, len(s.Values), s.Rows)
+	default:
+		if len(s.Values) != s.Rows*s.Cols {
+			return nil, fmt.Errorf("graphblas: values length %+v does not match size %+v", len(s.Values), s.Rows*s.Cols)
+		}
+		if s.Format == FormatDenseVector && s.Cols != 1 {
+			return nil, fmt.Errorf("graphblas: vector columns %+v is invalid", s.Cols)
+		}
+		return s, nil
+	}
+}
+
+func (s *jsonMatrix[T]) validateCompressed(major, minor int) error {
+	if len(s.Pointers) != major+1 {
+		return fmt.Errorf("graphblas: pointers length %+v does not match %+v", len(s.Pointers), major+1)
+	}
+
+	if s.Pointers[0] != 0 || s.Pointers[major] != len(s.Values) || len(s.Indices) != len(s.Values) {
+		return fmt.Errorf("graphblas: pointers, indices and values lengths mismatch")
+	}
+
+	for i := 0; i < major; i++ {
+		if s.Pointers[i] > s.Pointers[i+1] {
+			return fmt.Errorf("graphblas: pointers are not ascending at %+v", i)
+		}
+
+		if err := s.validateIndices(s.Pointers[i], s.Pointers[i+1], minor); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateIndices checks indices[start:end] are strictly ascending, in range and the values non-zero
+func (s *jsonMatrix[T]) validateIndices(start, end, length int) error {
+	if len(s.Indices) != len(s.Values) {
+		return fmt.Errorf("graphblas: indices length %+v does not match values %+v", len(s.Indices), len(s.Values))
+	}
+
+	for i := start; i < end; i++ {
+		if s.Indices[i] < 0 || s.Indices[i] >= length {
+			return fmt.Errorf("graphblas: index '%+v' is invalid", s.Indices[i])
+		}
+
+		if i > start && s.Indices[i] <= s.Indices[i-1] {
+			return fmt.Errorf("graphblas: indices are not ascending at %+v", i)
+		}
+
+		if IsZero(s.Values[i]) {
+			return fmt.Errorf("graphblas: value at %+v is zero", i)
+		}
+	}
+
+	return nil
+}
+
+// UnmarshalMatrix decodes a matrix or vector in any of the JSON formats
+func UnmarshalMatrix[T constraints.Number](data []byte) (Matrix[T], error) {
+	header := struct {
+		Format string `json:"format"`
+	}{}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, err
+	}
+
+	var matrix interface {
+		Matrix[T]
+		json.Unmarshaler
+	}
+
+	switch header.Format {
+	case FormatCSR:
+		matrix = NewCSRMatrix[T](0, 0)
+	case FormatCSC:
+		matrix = NewCSCMatrix[T](0, 0)
+	case FormatDense:
+		matrix = NewDenseMatrixN[T](0, 0)
+	case FormatSparseVector:
+		matrix = NewSparseVector[T](0)
+	case FormatDenseVector:
+		matrix = NewDenseVectorN[T](0)
+	default:
+		return nil, fmt.Errorf("graphblas: format %q is unknown", header.Format)
+	}
+
+	if err := matrix.UnmarshalJSON(data); err != nil {
+		return nil, err
+	}
+
+	return matrix, nil
+}
+
+// UnmarshalVector decodes a vector in either of the JSON vector formats
+func UnmarshalVector[T constraints.Number](data []byte) (Vector[T], error) {
+	matrix, err := UnmarshalMatrix[T](data)
+	if err != nil {
+		return nil, err
+	}
+
+	vector, ok := matrix.(Vector[T])
+	if !ok {
+		return nil, fmt.Errorf("graphblas: %T is not a vector", matrix)
+	}
+
+	return vector, nil
+}
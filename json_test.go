@@ -0,0 +1,150 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package graphblas_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/rossmerr/graphblas"
+)
+
+func TestMatrix_JSON(t *testing.T) {
+	array := [][]float64{
+		{0, 2, 0},
+		{1, 0, 0},
+		{0, 0, 3},
+		{0, 4, 5},
+	}
+
+	tests := []struct {
+		name   string
+		s      graphblas.Matrix[float64]
+		format string
+	}{
+		{
+			name:   "DenseMatrix",
+			s:      graphblas.NewDenseMatrixFromArrayN(array),
+			format: graphblas.FormatDense,
+		},
+		{
+			name:   "CSCMatrix",
+			s:      graphblas.NewCSCMatrixFromArray(array),
+			format: graphblas.FormatCSC,
+		},
+		{
+			name:   "CSRMatrix",
+			s:      graphblas.NewCSRMatrixFromArray(array),
+			format: graphblas.FormatCSR,
+		},
+		{
+			name:   "DenseVector",
+			s:      graphblas.NewDenseVectorFromArrayN([]float64{0, 1.5, 0, 3}),
+			format: graphblas.FormatDenseVector,
+		},
+		{
+			name:   "SparseVector",
+			s:      graphblas.NewSparseVectorFromArray([]float64{0, 1.5, 0, 3}),
+			format: graphblas.FormatSparseVector,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.s)
+			if err != nil {
+				t.Fatalf("%+v Marshal error %+v", tt.name, err)
+			}
+
+			header := struct {
+				Format string `json:"format"`
+				Type   string `json:"type"`
+			}{}
+			json.Unmarshal(data, &header)
+			if header.Format != tt.format || header.Type != "float64" {
+				t.Errorf("%+v Marshal format = %+v %+v, want %+v float64", tt.name, header.Format, header.Type, tt.format)
+			}
+
+			m, err := graphblas.UnmarshalMatrix[float64](data)
+			if err != nil {
+				t.Fatalf("%+v UnmarshalMatrix error %+v", tt.name, err)
+			}
+
+			if m.Rows() != tt.s.Rows() || m.Columns() != tt.s.Columns() || m.Values() != tt.s.Values() {
+				t.Errorf("%+v UnmarshalMatrix size = %+v, want %+v", tt.name, m, tt.s)
+			}
+
+			for r := 0; r < tt.s.Rows(); r++ {
+				for c := 0; c < tt.s.Columns(); c++ {
+					if m.At(r, c) != tt.s.At(r, c) {
+						t.Errorf("%+v At(%+v, %+v) = %+v, want %+v", tt.name, r, c, m.At(r, c), tt.s.At(r, c))
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestMatrix_JSON_Unmarshal(t *testing.T) {
+	data := []byte(`{"rows":2,"cols":3,"format":"csr","type":"float64","pointers":[0,1,3],"indices":[2,0,1],"values":[7,8,9]}`)
+
+	s := graphblas.NewCSRMatrix[float64](0, 0)
+	if err := json.Unmarshal(data, s); err != nil {
+		t.Fatalf("Unmarshal error %+v", err)
+	}
+
+	want := graphblas.NewDenseMatrixFromArrayN([][]float64{
+		{0, 0, 7},
+		{8, 9, 0},
+	})
+	if !s.Equal(want) || s.Values() != 3 {
+		t.Errorf("Unmarshal = %+v, want %+v", s, want)
+	}
+
+	errors := []struct {
+		name string
+		data string
+	}{
+		{
+			name: "Type",
+			data: `{"rows":2,"cols":3,"format":"csr","type":"float32","pointers":[0,1,3],"indices":[2,0,1],"values":[7,8,9]}`,
+		},
+		{
+			name: "Format",
+			data: `{"rows":2,"cols":3,"format":"csc","type":"float64","pointers":[0,1,3],"indices":[2,0,1],"values":[7,8,9]}`,
+		},
+		{
+			name: "Pointers",
+			data: `{"rows":2,"cols":3,"format":"csr","type":"float64","pointers":[0,1],"indices":[2,0,1],"values":[7,8,9]}`,
+		},
+		{
+			name: "Indices",
+			data: `{"rows":2,"cols":3,"format":"csr","type":"float64","pointers":[0,1,3],"indices":[2,1,0],"values":[7,8,9]}`,
+		},
+	}
+	for _, tt := range errors {
+		t.Run(tt.name, func(t *testing.T) {
+			s := graphblas.NewCSRMatrix[float64](0, 0)
+			if err := json.Unmarshal([]byte(tt.data), s); err == nil {
+				t.Errorf("%+v Unmarshal expected an error", tt.name)
+			}
+		})
+	}
+}
+
+func TestVector_JSON_Unmarshal(t *testing.T) {
+	v, err := graphblas.UnmarshalVector[float64]([]byte(`{"rows":3,"cols":1,"format":"dense-vector","type":"float64","values":[0.25,0.5,0.25]}`))
+	if err != nil {
+		t.Fatalf("UnmarshalVector error %+v", err)
+	}
+
+	if v.Length() != 3 || v.AtVec(1) != 0.5 {
+		t.Errorf("UnmarshalVector = %+v, want %+v", v, []float64{0.25, 0.5, 0.25})
+	}
+
+	if _, err := graphblas.UnmarshalVector[float64]([]byte(`{"rows":1,"cols":1,"format":"dense","type":"float64","values":[1]}`)); err == nil {
+		t.Errorf("UnmarshalVector expected an error for a matrix")
+	}
+}
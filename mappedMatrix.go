@@ -0,0 +1,551 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package graphblas
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"reflect"
+	"unsafe"
+
+	"github.com/rossmerr/graphblas/constraints"
+)
+
+func init() {
+	RegisterMatrix(reflect.TypeOf((*MappedMatrix[float64])(nil)).Elem())
+}
+
+// On-disk layout of a mapped matrix, all fields are little-endian
+//
+//	offset  size             field
+//	0       8                magic "GBLASCSR" or "GBLASCSC"
+//	8       4                version, currently 1
+//	12      4                element type as a reflect.Kind
+//	16      8                rows
+//	24      8                columns
+//	32      8                number of non-zero values (nnz)
+//	40      8 * (major + 1)  int64 start offset of each row (csr) or column (csc)
+//	        8 * nnz          int64 column (csr) or row (csc) index of each value
+//	        sizeof(T) * nnz  values
+//
+// major is the number of rows for csr and columns for csc, the indices within a row (csr)
+// or column (csc) are strictly ascending
+const (
+	mappedMagicCSR    = "GBLASCSR"
+	mappedMagicCSC    = "GBLASCSC"
+	mappedVersion     = 1
+	mappedHeaderBytes = 40
+)
+
+// MappedMatrix read-only compressed storage by rows (CSR) or columns (CSC) backed by a memory-mapped file
+type MappedMatrix[T constraints.Number] struct {
+	r        int // number of rows in the sparse matrix
+	c        int // number of columns in the sparse matrix
+	csc      bool
+	data     []byte
+	pointers []int64
+	indices  []int64
+	values   []T
+}
+
+// OpenMappedMatrix maps the file at path written by WriteMappedCSR or WriteMappedCSC
+// the matrix is valid until Close is called
+func OpenMappedMatrix[T constraints.Number](path string) (*MappedMatrix[T], error) {
+	if !littleEndian() || unsafe.Sizeof(int(0)) != 8 {
+		return nil, fmt.Errorf("graphblas: mapped matrices need a little-endian 64-bit platform")
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	size := info.Size()
+	if size < mappedHeaderBytes {
+		return nil, fmt.Errorf("graphblas: %s is too small for a mapped matrix", path)
+	}
+
+	data, err := mmap(f, int(size))
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := newMappedMatrix[T](data)
+	if err != nil {
+		munmap(data)
+		return nil, fmt.Errorf("graphblas: %s: %w", path, err)
+	}
+
+	return s, nil
+}
+
+func newMappedMatrix[T constraints.Number](data []byte) (*MappedMatrix[T], error) {
+	s := &MappedMatrix[T]{data: data}
+
+	switch string(data[:8]) {
+	case mappedMagicCSR:
+	case mappedMagicCSC:
+		s.csc = true
+	default:
+		return nil, fmt.Errorf("magic %q is invalid", data[:8])
+	}
+
+	if version := binary.LittleEndian.Uint32(data[8:]); version != mappedVersion {
+		return nil, fmt.Errorf("version %+v is not supported", version)
+	}
+
+	if kind := reflect.Kind(binary.LittleEndian.Uint32(data[12:])); kind != kindOf[T]() {
+		return nil, fmt.Errorf("type %+v does not match %+v", kind, kindOf[T]())
+	}
+
+	s.r = int(binary.LittleEndian.Uint64(data[16:]))
+	s.c = int(binary.LittleEndian.Uint64(data[24:]))
+	nnz := int(binary.LittleEndian.Uint64(data[32:]))
+	if s.r < 0 || s.c < 0 || nnz < 0 {
+		return nil, fmt.Errorf("size does not match the header")
+	}
+
+	// every pointer and index takes 8 bytes, bounding major and nnz by the data keeps the sizes from overflowing
+	major := s.major()
+	limit := (len(data) - mappedHeaderBytes) / 8
+	if major >= limit || nnz > limit {
+		return nil, fmt.Errorf("size does not match the header")
+	}
+
+	width := int(unsafe.Sizeof(Zero[T]()))
+	if len(data) != mappedHeaderBytes+8*(major+1)+8*nnz+width*nnz {
+		return nil, fmt.Errorf("size does not match the header")
+	}
+
+	offset := mappedHeaderBytes
+	s.pointers = unsafe.Slice((*int64)(unsafe.Pointer(&data[offset])), major+1)
+	offset += 8 * (major + 1)
+
+	if nnz > 0 {
+		s.indices = unsafe.Slice((*int64)(unsafe.Pointer(&data[offset])), nnz)
+		offset += 8 * nnz
+		s.values = unsafe.Slice((*T)(unsafe.Pointer(&data[offset])), nnz)
+	}
+
+	if s.pointers[0] != 0 || s.pointers[major] != int64(nnz) {
+		return nil, fmt.Errorf("pointers do not match the number of values")
+	}
+
+	minor := s.minor()
+	for i := 0; i < major; i++ {
+		if s.pointers[i] > s.pointers[i+1] {
+			return nil, fmt.Errorf("pointers are not ascending at %+v", i)
+		}
+
+		for p := s.pointers[i]; p < s.pointers[i+1]; p++ {
+			if s.indices[p] < 0 || s.indices[p] >= int64(minor) {
+				return nil, fmt.Errorf("index %+v at %+v is out of range", s.indices[p], i)
+			}
+
+			if p > s.pointers[i] && s.indices[p-1] >= s.indices[p] {
+				return nil, fmt.Errorf("indices are not ascending at %+v", i)
+			}
+		}
+	}
+
+	return s, nil
+}
+
+// WriteMappedCSR writes the matrix in the mapped matrix layout
+func WriteMappedCSR[T constraints.Number](w io.Writer, s *CSRMatrix[T]) error {
+	return writeMapped(w, mappedMagicCSR, s.r, s.c, s.rowStart, s.cols, s.values)
+}
+
+// WriteMappedCSC writes the matrix in the mapped matrix layout
+func WriteMappedCSC[T constraints.Number](w io.Writer, s *CSCMatrix[T]) error {
+	return writeMapped(w, mappedMagicCSC, s.r, s.c, s.colStart, s.rows, s.values)
+}
+
+func writeMapped[T constraints.Number](w io.Writer, magic string, r, c int, pointers, indices []int, values []T) error {
+	if !littleEndian() || unsafe.Sizeof(int(0)) != 8 {
+		return fmt.Errorf("graphblas: mapped matrices need a little-endian 64-bit platform")
+	}
+
+	b := bufio.NewWriter(w)
+
+	header := make([]byte, mappedHeaderBytes)
+	copy(header, magic)
+	binary.LittleEndian.PutUint32(header[8:], mappedVersion)
+	binary.LittleEndian.PutUint32(header[12:], uint32(kindOf[T]()))
+	binary.LittleEndian.PutUint64(header[16:], uint64(r))
+	binary.LittleEndian.PutUint64(header[24:], uint64(c))
+	binary.LittleEndian.PutUint64(header[32:], uint64(len(values)))
+
+	if _, err := b.Write(header); err != nil {
+		return err
+	}
+
+	word := make([]byte, 8)
+	for _, slice := range [][]int{pointers, indices} {
+		for _, v := range slice {
+			binary.LittleEndian.PutUint64(word, uint64(v))
+			if _, err := b.Write(word); err != nil {
+				return err
+			}
+		}
+	}
+
+	// the platform is little-endian so the values are written as they are held in memory, as they are mapped back
+	if len(values) > 0 {
+		width := int(unsafe.Sizeof(values[0]))
+		if _, err := b.Write(unsafe.Slice((*byte)(unsafe.Pointer(&values[0])), width*len(values))); err != nil {
+			return err
+		}
+	}
+
+	return b.Flush()
+}
+
+// kindOf the reflect.Kind of T
+func kindOf[T constraints.Number]() reflect.Kind {
+	return reflect.TypeOf(Zero[T]()).Kind()
+}
+
+func littleEndian() bool {
+	v := uint16(1)
+	return *(*byte)(unsafe.Pointer(&v)) == 1
+}
+
+// Close unmaps the file, the matrix and any transpose of it must not be used afterwards
+func (s *MappedMatrix[T]) Close() error {
+	data := s.data
+	s.data, s.pointers, s.indices, s.values = nil, nil, nil, nil
+	return munmap(data)
+}
+
+func (s *MappedMatrix[T]) major() int {
+	if s.csc {
+		return s.c
+	}
+	return s.r
+}
+
+func (s *MappedMatrix[T]) minor() int {
+	if s.csc {
+		return s.r
+	}
+	return s.c
+}
+
+// compressed the pointers, indices and values read in place, int and int64 have the same size and layout on the
+// little-endian 64-bit platforms a matrix is mapped on
+func (s *MappedMatrix[T]) compressed() (start, index []int, values []T) {
+	start = unsafe.Slice((*int)(unsafe.Pointer(&s.pointers[0])), len(s.pointers))
+	if len(s.indices) > 0 {
+		index = unsafe.Slice((*int)(unsafe.Pointer(&s.indices[0])), len(s.indices))
+	}
+	return start, index, s.values
+}
+
+// index returns the pointer to the element at major, minor
+func (s *MappedMatrix[T]) index(major, minor int) (int, bool) {
+	start := int(s.pointers[major])
+	end := int(s.pointers[major+1])
+
+	for start < end {
+		p := (start + end) / 2
+		if int(s.indices[p]) > minor {
+			end = p
+		} else if int(s.indices[p]) < minor {
+			start = p + 1
+		} else {
+			return p, true
+		}
+	}
+
+	return start, false
+}
+
+// Columns the number of columns of the matrix
+func (s *MappedMatrix[T]) Columns() int {
+	return s.c
+}
+
+// Rows the number of rows of the matrix
+func (s *MappedMatrix[T]) Rows() int {
+	return s.r
+}
+
+// Update is not supported as the matrix is read-only
+func (s *MappedMatrix[T]) Update(r, c int, f func(T) T) {
+	log.Panicf("MappedMatrix is read-only")
+}
+
+// At returns the value of a matrix element at r-th, c-th
+func (s *MappedMatrix[T]) At(r, c int) T {
+	if r < 0 || r >= s.r {
+		log.Panicf("Row '%+v' is invalid", r)
+	}
+
+	if c < 0 || c >= s.c {
+		log.Panicf("Column '%+v' is invalid", c)
+	}
+
+	major, minor := r, c
+	if s.csc {
+		major, minor = c, r
+	}
+
+	if pointer, found := s.index(major, minor); found {
+		return s.values[pointer]
+	}
+
+	return Zero[T]()
+}
+
+// Set is not supported as the matrix is read-only
+func (s *MappedMatrix[T]) Set(r, c int, value T) {
+	log.Panicf("MappedMatrix is read-only")
+}
+
+// vector returns the major-th row (csr) or column (csc)
+func (s *MappedMatrix[T]) vector(major, length int) *SparseVector[T] {
+	start := s.pointers[major]
+	end := s.pointers[major+1]
+
+	vector := newSparseVector[T](length, int(end-start))
+	for i := start; i < end; i++ {
+		vector.indices[i-start] = int(s.indices[i])
+		vector.values[i-start] = s.values[i]
+	}
+
+	return vector
+}
+
+// scan returns the minor-th column (csr) or row (csc) by searching each row (csr) or column (csc)
+func (s *MappedMatrix[T]) scan(minor int) *SparseVector[T] {
+	vector := NewSparseVector[T](s.major())
+	for major := 0; major < s.major(); major++ {
+		if pointer, found := s.index(major, minor); found {
+			vector.indices = append(vector.indices, major)
+			vector.values = append(vector.values, s.values[pointer])
+		}
+	}
+
+	return vector
+}
+
+// ColumnsAt return the columns at c-th
+func (s *MappedMatrix[T]) ColumnsAt(c int) VectorLogial[T] {
+	if c < 0 || c >= s.c {
+		log.Panicf("Column '%+v' is invalid", c)
+	}
+
+	if s.csc {
+		return s.vector(c, s.r)
+	}
+
+	return s.scan(c)
+}
+
+// RowsAt return the rows at r-th
+func (s *MappedMatrix[T]) RowsAt(r int) VectorLogial[T] {
+	if r < 0 || r >= s.r {
+		log.Panicf("Row '%+v' is invalid", r)
+	}
+
+	if s.csc {
+		return s.scan(r)
+	}
+
+	return s.vector(r, s.c)
+}
+
+// RowsAtToArray return the rows at r-th
+func (s *MappedMatrix[T]) RowsAtToArray(r int) []T {
+	if r < 0 || r >= s.r {
+		log.Panicf("Row '%+v' is invalid", r)
+	}
+
+	rows := make([]T, s.c)
+	for iterator := s.RowsAt(r).Enumerate(); iterator.HasNext(); {
+		c, _, value := iterator.Next()
+		rows[c] = value
+	}
+
+	return rows
+}
+
+// CopyLogical copies the matrix into memory
+func (s *MappedMatrix[T]) CopyLogical() MatrixLogical[T] {
+	return s.Copy()
+}
+
+// Copy copies the matrix into a CSRMatrix or CSCMatrix
+func (s *MappedMatrix[T]) Copy() Matrix[T] {
+	pointers := make([]int, len(s.pointers))
+	for i, v := range s.pointers {
+		pointers[i] = int(v)
+	}
+
+	indices := make([]int, len(s.indices))
+	for i, v := range s.indices {
+		indices[i] = int(v)
+	}
+
+	values := make([]T, len(s.values))
+	copy(values, s.values)
+
+	if s.csc {
+		return &CSCMatrix[T]{r: s.r, c: s.c, values: values, rows: indices, colStart: pointers}
+	}
+
+	return &CSRMatrix[T]{r: s.r, c: s.c, values: values, cols: indices, rowStart: pointers}
+}
+
+// MarshalJSON encodes the matrix in the csr or csc JSON format, the mapping is read in place
+func (s *MappedMatrix[T]) MarshalJSON() ([]byte, error) {
+	format := FormatCSR
+	if s.csc {
+		format = FormatCSC
+	}
+
+	j := newJSONMatrix[T](s.r, s.c, format)
+	start, index, values := s.compressed()
+	j.Pointers = start
+	j.Indices = index
+	if len(values) > 0 {
+		j.Values = values
+	}
+	return json.Marshal(j)
+}
+
+// Scalar multiplication of a matrix by alpha
+func (s *MappedMatrix[T]) Scalar(alpha T) Matrix[T] {
+	return Scalar[T](context.Background(), s, alpha)
+}
+
+// Multiply multiplies a matrix by another matrix
+func (s *MappedMatrix[T]) Multiply(m Matrix[T]) Matrix[T] {
+	matrix := newCSRMatrix[T](s.Rows(), m.Columns(), 0)
+	MatrixMatrixMultiply[T](context.Background(), s, m, nil, matrix)
+	return matrix
+}
+
+// Add addition of a matrix by another matrix
+func (s *MappedMatrix[T]) Add(m Matrix[T]) Matrix[T] {
+	matrix := s.Copy()
+	Add[T](context.Background(), s, m, nil, matrix)
+	return matrix
+}
+
+// Subtract subtracts one matrix from another matrix
+func (s *MappedMatrix[T]) Subtract(m Matrix[T]) Matrix[T] {
+	matrix := m.Copy()
+	Subtract[T](context.Background(), s, m, nil, matrix)
+	return matrix
+}
+
+// Negative the negative of a matrix
+func (s *MappedMatrix[T]) Negative() MatrixLogical[T] {
+	matrix := s.Copy()
+	Negative[T](context.Background(), s, nil, matrix)
+	return matrix
+}
+
+// Transpose swaps the rows and columns, the transpose shares the mapping
+func (s *MappedMatrix[T]) Transpose() MatrixLogical[T] {
+	return &MappedMatrix[T]{
+		r:        s.c,
+		c:        s.r,
+		csc:      !s.csc,
+		data:     s.data,
+		pointers: s.pointers,
+		indices:  s.indices,
+		values:   s.values,
+	}
+}
+
+// Equal the two matrices are equal
+func (s *MappedMatrix[T]) Equal(m MatrixLogical[T]) bool {
+	return Equal[T](context.Background(), s, m)
+}
+
+// NotEqual the two matrices are not equal
+func (s *MappedMatrix[T]) NotEqual(m MatrixLogical[T]) bool {
+	return NotEqual[T](context.Background(), s, m)
+}
+
+// Size of the matrix
+func (s *MappedMatrix[T]) Size() int {
+	return s.r * s.c
+}
+
+// Values the number of non-zero elements in the matrix
+func (s *MappedMatrix[T]) Values() int {
+	return len(s.values)
+}
+
+// Clear is not supported as the matrix is read-only
+func (s *MappedMatrix[T]) Clear() {
+	log.Panicf("MappedMatrix is read-only")
+}
+
+// Enumerate iterates through all non-zero elements, order is not guaranteed
+func (s *MappedMatrix[T]) Enumerate() Enumerate[T] {
+	return &mappedMatrixIterator[T]{matrix: s}
+}
+
+type mappedMatrixIterator[T constraints.Number] struct {
+	matrix *MappedMatrix[T]
+	major  int
+	index  int
+}
+
+// HasNext checks the iterator has any more values
+func (s *mappedMatrixIterator[T]) HasNext() bool {
+	return s.index < len(s.matrix.values)
+}
+
+// Next moves the iterator and returns the row, column and value
+func (s *mappedMatrixIterator[T]) Next() (int, int, T) {
+	for int(s.matrix.pointers[s.major+1]) <= s.index {
+		s.major++
+	}
+
+	minor := int(s.matrix.indices[s.index])
+	value := s.matrix.values[s.index]
+	s.index++
+
+	if s.matrix.csc {
+		return minor, s.major, value
+	}
+
+	return s.major, minor, value
+}
+
+// Map is not supported as the matrix is read-only
+func (s *MappedMatrix[T]) Map() Map[T] {
+	log.Panicf("MappedMatrix is read-only")
+	return nil
+}
+
+// Element of the mask for each tuple that exists in the matrix for which the value of the tuple cast to Boolean is true
+func (s *MappedMatrix[T]) Element(r, c int) bool {
+	return s.At(r, c) > Default[T]()
+}
+
+// Format implements fmt.Formatter, see format for the verbs supported
+func (s *MappedMatrix[T]) Format(f fmt.State, verb rune) {
+	format[T](f, verb, s, layoutTriples)
+}
@@ -0,0 +1,274 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package graphblas_test
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rossmerr/graphblas"
+)
+
+func writeMapped(t *testing.T, write func(f *os.File) error) string {
+	path := filepath.Join(t.TempDir(), "matrix.bin")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("Create error %+v", err)
+	}
+	defer f.Close()
+
+	if err := write(f); err != nil {
+		t.Fatalf("Write error %+v", err)
+	}
+
+	return path
+}
+
+func TestMappedMatrix(t *testing.T) {
+	array := [][]float64{
+		{0, 2, 0, 1},
+		{1, 0, 0, 0},
+		{0, 0, 3, 0},
+	}
+	csr := graphblas.NewCSRMatrixFromArray(array)
+	csc := graphblas.NewCSCMatrixFromArray(array)
+
+	tests := []struct {
+		name string
+		path string
+	}{
+		{
+			name: "CSRMatrix",
+			path: writeMapped(t, func(f *os.File) error { return graphblas.WriteMappedCSR[float64](f, csr) }),
+		},
+		{
+			name: "CSCMatrix",
+			path: writeMapped(t, func(f *os.File) error { return graphblas.WriteMappedCSC[float64](f, csc) }),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := graphblas.OpenMappedMatrix[float64](tt.path)
+			if err != nil {
+				t.Fatalf("%+v OpenMappedMatrix error %+v", tt.name, err)
+			}
+			defer s.Close()
+
+			if s.Rows() != 3 || s.Columns() != 4 || s.Values() != 4 {
+				t.Errorf("%+v size = %+v, %+v, %+v want 3, 4, 4", tt.name, s.Rows(), s.Columns(), s.Values())
+			}
+
+			for r := range array {
+				for c := range array[r] {
+					if s.At(r, c) != array[r][c] {
+						t.Errorf("%+v At(%+v, %+v) = %+v, want %+v", tt.name, r, c, s.At(r, c), array[r][c])
+					}
+				}
+			}
+
+			count := 0
+			for iterator := s.Enumerate(); iterator.HasNext(); {
+				r, c, v := iterator.Next()
+				if array[r][c] != v {
+					t.Errorf("%+v Enumerate(%+v, %+v) = %+v, want %+v", tt.name, r, c, v, array[r][c])
+				}
+				count++
+			}
+			if count != 4 {
+				t.Errorf("%+v Enumerate count = %+v, want %+v", tt.name, count, 4)
+			}
+
+			if !s.Copy().Equal(csr) {
+				t.Errorf("%+v Copy = %+v, want %+v", tt.name, s.Copy(), csr)
+			}
+
+			transpose := s.Transpose()
+			if transpose.At(3, 0) != 1 || transpose.Rows() != 4 {
+				t.Errorf("%+v Transpose At(3, 0) = %+v, want %+v", tt.name, transpose.At(3, 0), 1)
+			}
+
+			x := graphblas.NewDenseVectorFromArrayN([]float64{1, 1, 1, 1})
+			y := graphblas.NewDenseVectorN[float64](3)
+			graphblas.MatrixVectorMultiply[float64](context.Background(), s, x, nil, y)
+			want := graphblas.NewDenseVectorFromArrayN([]float64{3, 1, 3})
+			if !y.Equal(want) {
+				t.Errorf("%+v MatrixVectorMultiply = %+v, want %+v", tt.name, y, want)
+			}
+
+			sum := graphblas.ReduceMatrixToScalar[float64](context.Background(), s, nil)
+			if sum != 7 {
+				t.Errorf("%+v ReduceMatrixToScalar = %+v, want %+v", tt.name, sum, 7)
+			}
+		})
+	}
+}
+
+func TestMappedMatrix_Type(t *testing.T) {
+	csr := graphblas.NewCSRMatrixFromArray([][]int32{{1, 0}, {0, 2}})
+	path := writeMapped(t, func(f *os.File) error { return graphblas.WriteMappedCSR[int32](f, csr) })
+
+	if _, err := graphblas.OpenMappedMatrix[float64](path); err == nil {
+		t.Errorf("OpenMappedMatrix expected a type error")
+	}
+
+	s, err := graphblas.OpenMappedMatrix[int32](path)
+	if err != nil {
+		t.Fatalf("OpenMappedMatrix error %+v", err)
+	}
+	defer s.Close()
+
+	if s.At(1, 1) != 2 {
+		t.Errorf("At(1, 1) = %+v, want %+v", s.At(1, 1), 2)
+	}
+}
+
+func TestMappedMatrix_Int(t *testing.T) {
+	csr := graphblas.NewCSRMatrixFromArray([][]int{{1, 0, -3}, {0, 2, 0}})
+	path := writeMapped(t, func(f *os.File) error { return graphblas.WriteMappedCSR[int](f, csr) })
+
+	s, err := graphblas.OpenMappedMatrix[int](path)
+	if err != nil {
+		t.Fatalf("OpenMappedMatrix error %+v", err)
+	}
+	defer s.Close()
+
+	if !s.Copy().Equal(csr) {
+		t.Errorf("Copy = %+v, want %+v", s.Copy(), csr)
+	}
+}
+
+func TestMappedMatrix_Indices(t *testing.T) {
+	csr := graphblas.NewCSRMatrixFromArray([][]float64{{1, 2}})
+	path := writeMapped(t, func(f *os.File) error { return graphblas.WriteMappedCSR[float64](f, csr) })
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile error %+v", err)
+	}
+
+	// the header is 40 bytes followed by 2 row pointers, so the second column index is at 64
+	tests := []struct {
+		name  string
+		index byte
+	}{
+		{
+			name:  "OutOfRange",
+			index: 2,
+		},
+		{
+			name:  "Descending",
+			index: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			corrupt := append([]byte{}, data...)
+			corrupt[64] = tt.index
+			path := filepath.Join(t.TempDir(), "matrix.bin")
+			if err := os.WriteFile(path, corrupt, 0o600); err != nil {
+				t.Fatalf("WriteFile error %+v", err)
+			}
+
+			if s, err := graphblas.OpenMappedMatrix[float64](path); err == nil {
+				s.Close()
+				t.Errorf("%+v OpenMappedMatrix expected an index error", tt.name)
+			}
+		})
+	}
+}
+
+func TestMappedMatrix_Header(t *testing.T) {
+	csr := graphblas.NewCSRMatrix[float64](2, 2)
+	path := writeMapped(t, func(f *os.File) error { return graphblas.WriteMappedCSR[float64](f, csr) })
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile error %+v", err)
+	}
+
+	// the rows are at 16 and the values at 32, 2^60 values wrap 16 bytes each back to the size of the file
+	tests := []struct {
+		name   string
+		offset int
+		value  uint64
+	}{
+		{
+			name:   "Values",
+			offset: 32,
+			value:  1 << 60,
+		},
+		{
+			name:   "Rows",
+			offset: 16,
+			value:  1<<63 - 1,
+		},
+		{
+			name:   "Negative",
+			offset: 24,
+			value:  1 << 63,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			corrupt := append([]byte{}, data...)
+			binary.LittleEndian.PutUint64(corrupt[tt.offset:], tt.value)
+			path := filepath.Join(t.TempDir(), "matrix.bin")
+			if err := os.WriteFile(path, corrupt, 0o600); err != nil {
+				t.Fatalf("WriteFile error %+v", err)
+			}
+
+			if s, err := graphblas.OpenMappedMatrix[float64](path); err == nil {
+				s.Close()
+				t.Errorf("%+v OpenMappedMatrix expected a size error", tt.name)
+			}
+		})
+	}
+}
+
+func TestMappedMatrix_JSON(t *testing.T) {
+	csr := graphblas.NewCSRMatrixFromArray([][]float64{{1, 0, 3}, {0, 2, 0}})
+	csc := graphblas.NewCSCMatrixFromArray([][]float64{{1, 0, 3}, {0, 2, 0}})
+
+	tests := []struct {
+		name string
+		path string
+		want graphblas.Matrix[float64]
+	}{
+		{
+			name: "CSR",
+			path: writeMapped(t, func(f *os.File) error { return graphblas.WriteMappedCSR[float64](f, csr) }),
+			want: csr,
+		},
+		{
+			name: "CSC",
+			path: writeMapped(t, func(f *os.File) error { return graphblas.WriteMappedCSC[float64](f, csc) }),
+			want: csc,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := graphblas.OpenMappedMatrix[float64](tt.path)
+			if err != nil {
+				t.Fatalf("%+v OpenMappedMatrix error %+v", tt.name, err)
+			}
+			defer s.Close()
+
+			data, err := json.Marshal(s)
+			if err != nil {
+				t.Fatalf("%+v Marshal error %+v", tt.name, err)
+			}
+
+			want, _ := json.Marshal(tt.want)
+			if string(data) != string(want) {
+				t.Errorf("%+v Marshal = %s, want %s", tt.name, data, want)
+			}
+		})
+	}
+}
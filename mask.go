@@ -49,3 +49,18 @@ func (s *EmptyMask) Rows() int {
 func (s *EmptyMask) Element(r, c int) bool {
 	return false
 }
+
+// Complement is a mask selecting the elements the wrapped mask does not
+type Complement struct {
+	Mask
+}
+
+// NewComplement returns a Complement of the mask
+func NewComplement(mask Mask) *Complement {
+	return &Complement{Mask: mask}
+}
+
+// Element of the mask is true where the wrapped mask is false
+func (s *Complement) Element(r, c int) bool {
+	return !s.Mask.Element(r, c)
+}
@@ -8,6 +8,7 @@ package graphblas
 import (
 	"log"
 	"reflect"
+	"strings"
 
 	"github.com/rossmerr/graphblas/constraints"
 )
@@ -16,16 +17,26 @@ var sparseMatrixRegistry = make(map[string]reflect.Type)
 
 // RegisterMatrix add's the sparse matrix to the registry
 func RegisterMatrix(matrix reflect.Type) {
-	if _, found := sparseMatrixRegistry[matrix.Name()]; found {
-		log.Panicf("Already registered Matrix %q.", matrix.Name())
+	name := registryName(matrix)
+	if _, found := sparseMatrixRegistry[name]; found {
+		log.Panicf("Already registered Matrix %q.", name)
 	}
-	sparseMatrixRegistry[matrix.Name()] = matrix
+	sparseMatrixRegistry[name] = matrix
 
 }
 
 // IsSparseMatrix is 's' a sparse matrix
 func IsSparseMatrix[T constraints.Type](s MatrixLogical[T]) bool {
 	t := reflect.TypeOf(s).Elem()
-	_, found := sparseMatrixRegistry[t.Name()]
+	_, found := sparseMatrixRegistry[registryName(t)]
 	return found
 }
+
+// registryName the name of the type without its type arguments so every instantiation is registered
+func registryName(t reflect.Type) string {
+	name := t.Name()
+	if i := strings.Index(name, "["); i >= 0 {
+		return name[:i]
+	}
+	return name
+}
@@ -44,3 +44,13 @@ func TestSparseMatrixRegistry_IsSparseMatrix(t *testing.T) {
 		})
 	}
 }
+
+func TestSparseMatrixRegistry_IsSparseMatrix_Instantiations(t *testing.T) {
+	if !graphblas.IsSparseMatrix[int](graphblas.NewCSRMatrix[int](2, 2)) {
+		t.Errorf("CSRMatrix[int] IsSparseMatrix = false, want true")
+	}
+
+	if graphblas.IsSparseMatrix[int](graphblas.NewDenseMatrixN[int](2, 2)) {
+		t.Errorf("DenseMatrix[int] IsSparseMatrix = true, want false")
+	}
+}
@@ -128,6 +128,31 @@ func TestMatrix_SparseEnumerate(t *testing.T) {
 	}
 }
 
+func TestMatrix_CSREnumerate_Wide(t *testing.T) {
+	// rows with elements far apart and empty rows between them
+	columns := 1000000
+	rows := []int{0, 0, 2, 2, 2, 4}
+	cols := []int{0, columns - 1, 1, 500000, columns - 2, 7}
+	values := []float64{1, 2, 3, 4, 5, 6}
+
+	s := graphblas.NewCSRMatrixFromTuples(5, columns, rows, cols, values, nil)
+
+	i := 0
+	for iterator := s.Enumerate(); iterator.HasNext(); i++ {
+		r, c, value := iterator.Next()
+		if i >= len(values) {
+			t.Fatalf("CSRMatrix Enumerate returned more than %+v elements", len(values))
+		}
+		if r != rows[i] || c != cols[i] || value != values[i] {
+			t.Errorf("CSRMatrix Enumerate = (%+v, %+v, %+v), want (%+v, %+v, %+v)", r, c, value, rows[i], cols[i], values[i])
+		}
+	}
+
+	if i != len(values) {
+		t.Errorf("CSRMatrix Enumerate returned %+v elements, want %+v", i, len(values))
+	}
+}
+
 func TestMatrix_SparseMap(t *testing.T) {
 	setup := func(m graphblas.Matrix[float64]) {
 		m.Set(0, 0, 9)
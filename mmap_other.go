@@ -0,0 +1,27 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+//go:build !unix
+
+package graphblas
+
+import (
+	"io"
+	"os"
+)
+
+// mmap falls back to reading the first size bytes of the file into memory
+func mmap(f *os.File, size int) ([]byte, error) {
+	data := make([]byte, size)
+	if _, err := io.ReadFull(f, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// munmap releases a mapping returned by mmap
+func munmap(data []byte) error {
+	return nil
+}
@@ -0,0 +1,23 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+//go:build unix
+
+package graphblas
+
+import (
+	"os"
+	"syscall"
+)
+
+// mmap maps the first size bytes of the file read-only
+func mmap(f *os.File, size int) ([]byte, error) {
+	return syscall.Mmap(int(f.Fd()), 0, size, syscall.PROT_READ, syscall.MAP_SHARED)
+}
+
+// munmap releases a mapping returned by mmap
+func munmap(data []byte) error {
+	return syscall.Munmap(data)
+}
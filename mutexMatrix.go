@@ -6,6 +6,8 @@
 package graphblas
 
 import (
+	"encoding/json"
+	"fmt"
 	"sync"
 
 	"github.com/rossmerr/graphblas/constraints"
@@ -189,3 +191,41 @@ func (s *MutexMatrix[T]) Element(r, c int) bool {
 
 	return s.matrix.Element(r, c)
 }
+
+// MarshalJSON encodes the wrapped matrix
+func (s *MutexMatrix[T]) MarshalJSON() ([]byte, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	return json.Marshal(s.matrix)
+}
+
+// UnmarshalJSON decodes into the wrapped matrix
+func (s *MutexMatrix[T]) UnmarshalJSON(data []byte) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.matrix == nil {
+		matrix, err := UnmarshalMatrix[T](data)
+		if err != nil {
+			return err
+		}
+		s.matrix = matrix
+		return nil
+	}
+
+	return json.Unmarshal(data, s.matrix)
+}
+
+// Format implements fmt.Formatter by formatting the wrapped matrix
+func (s *MutexMatrix[T]) Format(f fmt.State, verb rune) {
+	s.RLock()
+	defer s.RUnlock()
+
+	if formatter, ok := s.matrix.(fmt.Formatter); ok {
+		formatter.Format(f, verb)
+		return
+	}
+
+	format[T](f, verb, s.matrix, layoutTriples)
+}
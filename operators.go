@@ -7,6 +7,7 @@ package graphblas
 
 import (
 	"log"
+	"sort"
 	"strings"
 
 	"context"
@@ -16,7 +17,17 @@ import (
 	"github.com/rossmerr/graphblas/unaryop"
 )
 
-func multiply[T constraints.Number](ctx context.Context, s, m Matrix[T], mask Mask, matrix Matrix[T]) {
+// multiply computes each row of the product from the stored elements of that row of s and the matching rows
+// of m, the non-zeros of a sparse matrix and every element of a dense one, combined with the semiring
+//
+// an s stored by columns, a CSCMatrix or a MappedMatrix of one, is read in place as the rows of sᵀ and the
+// product found column by column as (mᵀ·sᵀ)ᵀ, so neither a CSR nor a CSC operand is copied when the other
+// operand is stored the same way or is a vector
+//
+// every element not excluded by the mask is overwritten, elements without any products are removed from
+// a sparse result and set to the zero of the semiring's monoid in a dense one, a CSRMatrix result of the row
+// by row product and a CSCMatrix result of the column by column product are built directly
+func multiply[T constraints.Number](ctx context.Context, s, m Matrix[T], semiring binaryop.Semiring[T], mask Mask, matrix Matrix[T]) {
 	if m.Rows() != s.Columns() {
 		log.Panicf("Can not multiply matrices found length mismatch %+v, %+v", m.Rows(), s.Columns())
 	}
@@ -33,51 +44,363 @@ func multiply[T constraints.Number](ctx context.Context, s, m Matrix[T], mask Ma
 		log.Panicf("Can not apply mask found columns mismatch %+v, %+v", mask.Columns(), matrix.Columns())
 	}
 
-	for r := 0; r < s.Rows(); r++ {
-		rows := s.RowsAt(r)
+	if columnMajor(s) {
+		sStart, sIndex, sValues := compressedColumns(s)
+		mStart, mIndex, mValues := compressedColumns(m)
+		p := &product[T]{
+			left:       compressed[T]{mStart, mIndex, mValues},
+			right:      compressed[T]{sStart, sIndex, sValues},
+			transposed: true,
+		}
+		p.multiply(ctx, semiring, mask, matrix)
+		return
+	}
+
+	sStart, sIndex, sValues := compressedRows(s)
+	mStart, mIndex, mValues := compressedRows(m)
+	p := &product[T]{
+		left:  compressed[T]{sStart, sIndex, sValues},
+		right: compressed[T]{mStart, mIndex, mValues},
+	}
+	p.multiply(ctx, semiring, mask, matrix)
+}
+
+// compressed the stored elements of a matrix by a major index, rows or columns, the elements of major i are
+// index[start[i]:start[i+1]] with their values
+type compressed[T constraints.Number] struct {
+	start  []int
+	index  []int
+	values []T
+}
 
-		for c := 0; c < m.Columns(); c++ {
-			column := m.ColumnsAt(c)
+// product the rows of left times right, transposed when left is mᵀ and right is sᵀ so each row is a column
+// of the result and the operands of the semiring's multiply are swapped back
+type product[T constraints.Number] struct {
+	left       compressed[T]
+	right      compressed[T]
+	transposed bool
+}
 
-			sum := Default[T]()
-			for l := 0; l < rows.Length(); l++ {
-				select {
-				case <-ctx.Done():
-					return
-				default:
-					vC := column.AtVec(l)
-					vR := rows.AtVec(l)
-					sum += vR * vC
+// element of the mask at the major and minor index of the result
+func (s *product[T]) element(mask Mask, major, minor int) bool {
+	if s.transposed {
+		return mask.Element(minor, major)
+	}
+	return mask.Element(major, minor)
+}
+
+func (s *product[T]) at(matrix Matrix[T], major, minor int) T {
+	if s.transposed {
+		return matrix.At(minor, major)
+	}
+	return matrix.At(major, minor)
+}
+
+func (s *product[T]) set(matrix Matrix[T], major, minor int, value T) {
+	if s.transposed {
+		matrix.Set(minor, major, value)
+	} else {
+		matrix.Set(major, minor, value)
+	}
+}
+
+// built the arrays of a result stored by the same major index as the product, when there is one
+func (s *product[T]) built(matrix Matrix[T]) (*compressed[T], bool) {
+	if s.transposed {
+		if csc, ok := matrix.(*CSCMatrix[T]); ok {
+			return &compressed[T]{csc.colStart, csc.rows, csc.values}, true
+		}
+		return nil, false
+	}
+
+	if csr, ok := matrix.(*CSRMatrix[T]); ok {
+		return &compressed[T]{csr.rowStart, csr.cols, csr.values}, true
+	}
+	return nil, false
+}
+
+func (s *product[T]) multiply(ctx context.Context, semiring binaryop.Semiring[T], mask Mask, matrix Matrix[T]) {
+	add := semiring.Add()
+	plus := binaryop.Operator(add)
+	times := semiring.Multiply()
+
+	majors, minors := matrix.Rows(), matrix.Columns()
+	if s.transposed {
+		majors, minors = minors, majors
+	}
+
+	// sums accumulates a row of the product, touched holds the columns it reached
+	sums := make([]T, minors)
+	seen := make([]bool, minors)
+	touched := []int{}
+
+	existing, direct := s.built(matrix)
+	var start, index []int
+	var values []T
+	if direct {
+		start = make([]int, 0, majors+1)
+	}
+
+	// the value left where nothing was added, only needs writing when it differs from what is already there
+	empty := add.Zero()
+	if IsSparseMatrix[T](matrix) {
+		empty = Default[T]()
+	}
+	clear := !direct && (matrix.Values() > 0 || empty != Default[T]())
+
+	// a product with a single column has one element per row, so a row the mask excludes keeps its existing value
+	// whatever the products sum to and the row of s is skipped without reading it, this is what lets a masked mxv
+	// such as the pull step of a breadth-first search only pay for the rows the mask lets through
+	single := minors == 1
+
+	left, right := s.left, s.right
+	for r := 0; r < majors; r++ {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		touched = touched[:0]
+		end := left.start[r+1]
+		if single && s.element(mask, r, 0) {
+			end = left.start[r]
+		}
+		for i := left.start[r]; i < end; i++ {
+			l, vR := left.index[i], left.values[i]
+			for j := right.start[l]; j < right.start[l+1]; j++ {
+				c := right.index[j]
+				var product T
+				if s.transposed {
+					product = times.Apply(right.values[j], vR)
+				} else {
+					product = times.Apply(vR, right.values[j])
+				}
+				if !seen[c] {
+					seen[c] = true
+					touched = append(touched, c)
+					sums[c] = product
+				} else {
+					sums[c] = plus.Apply(sums[c], product)
 				}
 			}
+		}
 
-			if !mask.Element(r, c) {
-				matrix.Set(r, c, sum)
+		if direct {
+			start = append(start, len(index))
+			index, values = s.merge(existing, mask, r, touched, sums, index, values)
+		} else {
+			for _, c := range touched {
+				if !s.element(mask, r, c) {
+					s.set(matrix, r, c, sums[c])
+				}
+			}
+
+			if clear {
+				for c := 0; c < minors; c++ {
+					if !seen[c] && !s.element(mask, r, c) && s.at(matrix, r, c) != empty {
+						s.set(matrix, r, c, empty)
+					}
+				}
+			}
+		}
+
+		for _, c := range touched {
+			seen[c] = false
+		}
+	}
+
+	if direct {
+		start = append(start, len(index))
+		switch result := matrix.(type) {
+		case *CSRMatrix[T]:
+			result.rowStart, result.cols, result.values = start, index, values
+		case *CSCMatrix[T]:
+			result.colStart, result.rows, result.values = start, index, values
+		}
+	}
+}
+
+// merge appends row r of the product onto index and values, keeping the existing values the mask excludes
+func (s *product[T]) merge(existing *compressed[T], mask Mask, r int, touched []int, sums []T, index []int, values []T) ([]int, []T) {
+	sort.Ints(touched)
+
+	stored := existing.index[existing.start[r]:existing.start[r+1]]
+	storedValues := existing.values[existing.start[r]:existing.start[r+1]]
+
+	i, j := 0, 0
+	for i < len(touched) || j < len(stored) {
+		c := 0
+		var value T
+		found, computed := false, false
+		switch {
+		case j == len(stored) || (i < len(touched) && touched[i] < stored[j]):
+			c, computed = touched[i], true
+			i++
+		case i == len(touched) || stored[j] < touched[i]:
+			c, value, found = stored[j], storedValues[j], true
+			j++
+		default:
+			c, value, found, computed = touched[i], storedValues[j], true, true
+			i++
+			j++
+		}
+
+		if s.element(mask, r, c) {
+			if found {
+				index = append(index, c)
+				values = append(values, value)
+			}
+		} else if computed && sums[c] != Default[T]() {
+			index = append(index, c)
+			values = append(values, sums[c])
+		}
+	}
+
+	return index, values
+}
+
+// columnMajor whether s is stored by columns, so compressedColumns reads it in place
+func columnMajor[T constraints.Number](s Matrix[T]) bool {
+	switch s := s.(type) {
+	case *CSCMatrix[T]:
+		return true
+	case *MappedMatrix[T]:
+		return s.csc
+	}
+	return false
+}
+
+// compressedRows returns the stored elements of s by rows, the non-zeros of a sparse matrix and every element
+// of a dense one, a CSRMatrix or a MappedMatrix stored by rows is read in place rather than copied and the rows of
+// a SparseVector only need their start offsets
+func compressedRows[T constraints.Number](s Matrix[T]) (rowStart, cols []int, values []T) {
+	switch s := s.(type) {
+	case *CSRMatrix[T]:
+		return s.rowStart, s.cols, s.values
+	case *MappedMatrix[T]:
+		if !s.csc {
+			return s.compressed()
+		}
+	case *SparseVector[T]:
+		// each row of the single column holds at most one element
+		rowStart = make([]int, s.l+1)
+		for i, r := range s.indices {
+			rowStart[r+1] = i + 1
+		}
+		for r := 0; r < s.l; r++ {
+			if rowStart[r+1] < rowStart[r] {
+				rowStart[r+1] = rowStart[r]
+			}
+		}
+		return rowStart, make([]int, len(s.indices)), s.values
+	}
+
+	if !IsSparseMatrix[T](s) {
+		rowStart = make([]int, s.Rows()+1)
+		for r := 0; r < s.Rows(); r++ {
+			for c := 0; c < s.Columns(); c++ {
+				cols = append(cols, c)
+				values = append(values, s.At(r, c))
 			}
+			rowStart[r+1] = len(cols)
 		}
+		return rowStart, cols, values
+	}
 
+	rows := []int{}
+	for iterator := s.Enumerate(); iterator.HasNext(); {
+		r, c, v := iterator.Next()
+		if v != Default[T]() {
+			rows = append(rows, r)
+			cols = append(cols, c)
+			values = append(values, v)
+		}
 	}
+
+	return buildCompressed(s.Rows(), s.Columns(), rows, cols, values, nil)
+}
+
+// compressedColumns returns the stored elements of s by columns, the non-zeros of a sparse matrix and every element
+// of a dense one, a CSCMatrix, a MappedMatrix stored by columns and the single column of a SparseVector are read
+// in place rather than copied
+func compressedColumns[T constraints.Number](s Matrix[T]) (colStart, rows []int, values []T) {
+	switch s := s.(type) {
+	case *CSCMatrix[T]:
+		return s.colStart, s.rows, s.values
+	case *MappedMatrix[T]:
+		if s.csc {
+			return s.compressed()
+		}
+	case *SparseVector[T]:
+		return []int{0, len(s.indices)}, s.indices, s.values
+	}
+
+	if !IsSparseMatrix[T](s) {
+		colStart = make([]int, s.Columns()+1)
+		for c := 0; c < s.Columns(); c++ {
+			for r := 0; r < s.Rows(); r++ {
+				rows = append(rows, r)
+				values = append(values, s.At(r, c))
+			}
+			colStart[c+1] = len(rows)
+		}
+		return colStart, rows, values
+	}
+
+	cols := []int{}
+	for iterator := s.Enumerate(); iterator.HasNext(); {
+		r, c, v := iterator.Next()
+		if v != Default[T]() {
+			rows = append(rows, r)
+			cols = append(cols, c)
+			values = append(values, v)
+		}
+	}
+
+	return buildCompressed(s.Columns(), s.Rows(), cols, rows, values, nil)
 }
 
 // MatrixMatrixMultiply multiplies a matrix by another matrix
 //
 // mxm
 func MatrixMatrixMultiply[T constraints.Number](ctx context.Context, s, m Matrix[T], mask Mask, matrix Matrix[T]) {
-	multiply(ctx, s, m, mask, matrix)
+	multiply(ctx, s, m, DefaultSemiringPlusTimes[T](), mask, matrix)
+}
+
+// MatrixMatrixMultiplyWithSemiring multiplies a matrix by another matrix over the semiring
+//
+// mxm
+func MatrixMatrixMultiplyWithSemiring[T constraints.Number](ctx context.Context, s, m Matrix[T], semiring binaryop.Semiring[T], mask Mask, matrix Matrix[T]) {
+	multiply(ctx, s, m, semiring, mask, matrix)
 }
 
 // VectorMatrixMultiply multiplies a vector by a matrix
 //
 // vxm
 func VectorMatrixMultiply[T constraints.Number](ctx context.Context, s Vector[T], m Matrix[T], mask Mask, vector Vector[T]) {
-	multiply[T](ctx, m, s, mask, vector)
+	multiply[T](ctx, m, s, DefaultSemiringPlusTimes[T](), mask, vector)
+}
+
+// VectorMatrixMultiplyWithSemiring multiplies a vector by a matrix over the semiring
+//
+// vxm
+func VectorMatrixMultiplyWithSemiring[T constraints.Number](ctx context.Context, s Vector[T], m Matrix[T], semiring binaryop.Semiring[T], mask Mask, vector Vector[T]) {
+	multiply[T](ctx, m, s, semiring, mask, vector)
 }
 
 // MatrixVectorMultiply multiplies a matrix by a vector
 //
 // mxv
 func MatrixVectorMultiply[T constraints.Number](ctx context.Context, s Matrix[T], m Vector[T], mask Mask, vector Vector[T]) {
-	multiply[T](ctx, s, m, mask, vector)
+	multiply[T](ctx, s, m, DefaultSemiringPlusTimes[T](), mask, vector)
+}
+
+// MatrixVectorMultiplyWithSemiring multiplies a matrix by a vector over the semiring
+//
+// mxv
+func MatrixVectorMultiplyWithSemiring[T constraints.Number](ctx context.Context, s Matrix[T], m Vector[T], semiring binaryop.Semiring[T], mask Mask, vector Vector[T]) {
+	multiply[T](ctx, s, m, semiring, mask, vector)
 }
 
 func elementWiseMultiply[T constraints.Number](ctx context.Context, s, m Matrix[T], mask Mask, matrix Matrix[T]) {
@@ -340,7 +663,7 @@ func Apply[T constraints.Number](ctx context.Context, in Matrix[T], mask Mask, u
 				return
 			default:
 				iterator.Map(func(r, c int, value T) T {
-					if mask.Element(r, c) {
+					if !mask.Element(r, c) {
 						return u.Apply(value)
 					}
 
@@ -359,10 +682,75 @@ func Apply[T constraints.Number](ctx context.Context, in Matrix[T], mask Mask, u
 		default:
 			r, c, value := iterator.Next()
 			if !mask.Element(r, c) {
-				matrix.Set(c, r, u.Apply(value))
+				matrix.Set(r, c, u.Apply(value))
+			}
+		}
+	}
+}
+
+// Select keeps the non-zero elements of s for which the operator is true, every element of matrix not excluded
+// by the mask is overwritten so the elements not kept are removed
+//
+//	C⟨M⟩ = select(f, A)
+func Select[T constraints.Number](ctx context.Context, s Matrix[T], mask Mask, op unaryop.SelectOp[T], matrix Matrix[T]) {
+	if mask == nil {
+		mask = NewEmptyMask(matrix.Rows(), matrix.Columns())
+	}
+
+	if mask.Rows() != matrix.Rows() {
+		log.Panicf("Can not apply mask found rows mismatch %+v, %+v", mask.Rows(), matrix.Rows())
+	}
+
+	if mask.Columns() != matrix.Columns() {
+		log.Panicf("Can not apply mask found columns mismatch %+v, %+v", mask.Columns(), matrix.Columns())
+	}
+
+	rows := []int{}
+	cols := []int{}
+	values := []T{}
+	for iterator := s.Enumerate(); iterator.HasNext(); {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+			r, c, v := iterator.Next()
+			if !IsZero(v) && !mask.Element(r, c) && op.Apply(r, c, v) {
+				rows = append(rows, r)
+				cols = append(cols, c)
+				values = append(values, v)
 			}
 		}
 	}
+
+	// the elements the mask excludes are kept as they are
+	removed := [][2]int{}
+	for iterator := matrix.Enumerate(); iterator.HasNext(); {
+		r, c, v := iterator.Next()
+		if IsZero(v) {
+			continue
+		}
+
+		if mask.Element(r, c) {
+			rows = append(rows, r)
+			cols = append(cols, c)
+			values = append(values, v)
+		} else {
+			removed = append(removed, [2]int{r, c})
+		}
+	}
+
+	if csr, ok := matrix.(*CSRMatrix[T]); ok {
+		csr.rowStart, csr.cols, csr.values = buildCompressed(csr.r, csr.c, rows, cols, values, nil)
+		return
+	}
+
+	for _, p := range removed {
+		matrix.Set(p[0], p[1], Default[T]())
+	}
+
+	for i := range rows {
+		matrix.Set(rows[i], cols[i], values[i])
+	}
 }
 
 // Negative the negative of a matrix
@@ -427,10 +815,23 @@ func Transpose[T constraints.Type](ctx context.Context, s MatrixLogical[T], mask
 
 // TransposeToCSR swaps the rows and columns and returns a compressed storage by rows (CSR) matrix
 func TransposeToCSR[T constraints.Number](ctx context.Context, s Matrix[T]) Matrix[T] {
-	matrix := NewCSRMatrix[T](s.Columns(), s.Rows())
+	// the tuples are built in one pass rather than set one at a time as each set shifts the rest of the row
+	rows := []int{}
+	cols := []int{}
+	values := []T{}
+	for iterator := s.Enumerate(); iterator.HasNext(); {
+		select {
+		case <-ctx.Done():
+			return NewCSRMatrixFromTuples(s.Columns(), s.Rows(), rows, cols, values, nil)
+		default:
+			r, c, value := iterator.Next()
+			rows = append(rows, c)
+			cols = append(cols, r)
+			values = append(values, value)
+		}
+	}
 
-	Transpose[T](ctx, s, nil, matrix)
-	return matrix
+	return NewCSRMatrixFromTuples(s.Columns(), s.Rows(), rows, cols, values, nil)
 }
 
 // TransposeToCSC swaps the rows and columns and returns a compressed storage by columns (CSC) matrix
@@ -580,6 +981,10 @@ func ReduceMatrixToVectorWithMonoID[T constraints.Number](ctx context.Context, s
 
 	vector := NewDenseVectorN[T](s.Columns())
 	for c := 0; c < s.Columns(); c++ {
+		if ctx.Err() != nil {
+			break
+		}
+
 		v := s.ColumnsAt(c)
 		scaler := ReduceVectorToScalarWithMonoID(ctx, v, monoID, mask)
 		vector.SetVec(c, scaler)
@@ -605,15 +1010,8 @@ func ReduceMatrixToScalar[T constraints.Number](ctx context.Context, s MatrixLog
 }
 
 // ReduceMatrixToScalarWithMonoID perform's a reduction on the Matrix
-// monoid used in the element-wise reduction operation
+// monoid used in the element-wise reduction operation, returns the monoid's zero once ctx is done
 func ReduceMatrixToScalarWithMonoID[T constraints.Number](ctx context.Context, s MatrixLogical[T], monoID binaryop.MonoID[T], mask Mask) T {
-	done := make(chan struct{})
-	slice := make(chan T)
-	defer close(slice)
-	defer close(done)
-
-	out := monoID.Reduce(done, slice)
-
 	if mask == nil {
 		mask = NewEmptyMask(s.Rows(), s.Columns())
 	}
@@ -626,23 +1024,21 @@ func ReduceMatrixToScalarWithMonoID[T constraints.Number](ctx context.Context, s
 		log.Panicf("Can not apply mask found columns mismatch %+v, %+v", mask.Columns(), s.Columns())
 	}
 
-	go func() {
-		for iterator := s.Enumerate(); iterator.HasNext(); {
-			select {
-			case <-ctx.Done():
-				return
-			default:
-				r, c, value := iterator.Next()
-				if !mask.Element(r, c) {
-					slice <- value
-				}
+	plus := binaryop.Operator(monoID)
+	result := monoID.Zero()
+	for iterator := s.Enumerate(); iterator.HasNext(); {
+		select {
+		case <-ctx.Done():
+			return monoID.Zero()
+		default:
+			r, c, value := iterator.Next()
+			if !mask.Element(r, c) {
+				result = plus.Apply(result, value)
 			}
 		}
-		done <- struct{}{}
-	}()
-
-	return <-out
+	}
 
+	return result
 }
 
 // AssignConstantVector the contents of a subset of a vector
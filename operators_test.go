@@ -6,9 +6,15 @@
 package graphblas_test
 
 import (
+	"math"
+	"os"
+	"runtime"
 	"testing"
+	"time"
 
 	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/binaryop"
+	"github.com/rossmerr/graphblas/unaryop"
 
 	"golang.org/x/net/context"
 )
@@ -171,6 +177,113 @@ func TestMatrix_MatrixVectorMultiply(t *testing.T) {
 	}
 }
 
+func TestMatrix_MatrixMatrixMultiply_Mask(t *testing.T) {
+	s := graphblas.NewCSRMatrixFromArray([][]float64{
+		{1, 2, 0},
+		{0, 0, 3},
+		{4, 0, 0},
+	})
+	m := graphblas.NewCSCMatrixFromArray([][]float64{
+		{0, 1, 0},
+		{1, 0, 0},
+		{0, 0, 2},
+	})
+
+	// the mask keeps the existing value at (0, 0) and (2, 2)
+	mask := graphblas.NewCSRMatrixFromArray([][]float64{
+		{1, 0, 0},
+		{0, 0, 0},
+		{0, 0, 1},
+	})
+
+	existing := [][]float64{
+		{9, 0, 9},
+		{9, 9, 0},
+		{0, 0, 9},
+	}
+	want := graphblas.NewDenseMatrixFromArrayN([][]float64{
+		{9, 1, 0},
+		{0, 0, 6},
+		{0, 4, 9},
+	})
+
+	tests := []struct {
+		name string
+		s    graphblas.Matrix[float64]
+	}{
+		{
+			name: "DenseMatrix",
+			s:    graphblas.NewDenseMatrixFromArrayN(existing),
+		},
+		{
+			name: "CSCMatrix",
+			s:    graphblas.NewCSCMatrixFromArray(existing),
+		},
+		{
+			name: "CSRMatrix",
+			s:    graphblas.NewCSRMatrixFromArray(existing),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			graphblas.MatrixMatrixMultiply[float64](context.Background(), s, m, mask, tt.s)
+			for r := 0; r < 3; r++ {
+				for c := 0; c < 3; c++ {
+					if tt.s.At(r, c) != want.At(r, c) {
+						t.Errorf("%+v MatrixMatrixMultiply At(%+v, %+v) = %+v, want %+v", tt.name, r, c, tt.s.At(r, c), want.At(r, c))
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestMatrix_Apply_Mask(t *testing.T) {
+	array := [][]float64{
+		{1, 2, 0},
+		{0, 0, 3},
+	}
+
+	// the mask keeps the existing value at (0, 1)
+	mask := graphblas.NewCSRMatrixFromArray([][]float64{
+		{0, 1, 0},
+		{0, 0, 0},
+	})
+	want := graphblas.NewDenseMatrixFromArrayN([][]float64{
+		{2, 2, 0},
+		{0, 0, 6},
+	})
+	double := unaryop.NewUnaryOp(func(v float64) float64 {
+		return 2 * v
+	})
+
+	tests := []struct {
+		name string
+		s    graphblas.Matrix[float64]
+	}{
+		{
+			name: "CSRMatrix",
+			s:    graphblas.NewCSRMatrixFromArray(array),
+		},
+		{
+			name: "DenseMatrix",
+			s:    graphblas.NewDenseMatrixFromArrayN(array),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			graphblas.Apply[float64](context.Background(), tt.s, mask, double, tt.s)
+			for r := 0; r < 2; r++ {
+				for c := 0; c < 3; c++ {
+					if tt.s.At(r, c) != want.At(r, c) {
+						t.Errorf("%+v Apply At(%+v, %+v) = %+v, want %+v", tt.name, r, c, tt.s.At(r, c), want.At(r, c))
+					}
+				}
+			}
+		})
+	}
+}
+
 func TestMatrix_ElementWiseMatrixMultiply(t *testing.T) {
 	array := [][]float64{
 		{0, 0, 0, 0, 0, 0, 0},
@@ -561,3 +674,369 @@ func TestMatrix_ReduceVectorToScalar(t *testing.T) {
 		})
 	}
 }
+
+func TestMatrix_Apply(t *testing.T) {
+	array := [][]float64{
+		{1, 2, 0},
+		{0, 0, 3},
+	}
+	want := graphblas.NewDenseMatrixFromArrayN([][]float64{
+		{1, 4, 0},
+		{0, 0, 9},
+	})
+	square := unaryop.NewUnaryOp(func(v float64) float64 {
+		return v * v
+	})
+
+	tests := []struct {
+		name   string
+		s      graphblas.Matrix[float64]
+		matrix func(s graphblas.Matrix[float64]) graphblas.Matrix[float64]
+	}{
+		{
+			name: "CSRMatrix",
+			s:    graphblas.NewCSRMatrixFromArray(array),
+			matrix: func(s graphblas.Matrix[float64]) graphblas.Matrix[float64] {
+				return graphblas.NewCSRMatrix[float64](2, 3)
+			},
+		},
+		{
+			name: "DenseMatrix",
+			s:    graphblas.NewDenseMatrixFromArrayN(array),
+			matrix: func(s graphblas.Matrix[float64]) graphblas.Matrix[float64] {
+				return graphblas.NewDenseMatrixN[float64](2, 3)
+			},
+		},
+		{
+			name: "InPlace",
+			s:    graphblas.NewCSCMatrixFromArray(array),
+			matrix: func(s graphblas.Matrix[float64]) graphblas.Matrix[float64] {
+				return s
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.matrix(tt.s)
+			graphblas.Apply[float64](context.Background(), tt.s, nil, square, got)
+			for r := 0; r < 2; r++ {
+				for c := 0; c < 3; c++ {
+					if got.At(r, c) != want.At(r, c) {
+						t.Errorf("%+v Apply At(%+v, %+v) = %+v, want %+v", tt.name, r, c, got.At(r, c), want.At(r, c))
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestMatrix_Select(t *testing.T) {
+	array := [][]float64{
+		{1, 5, 0},
+		{0, 2, 7},
+		{3, 0, 4},
+	}
+
+	// keep the upper triangle values above 1
+	op := unaryop.NewSelectOp(func(r, c int, value float64) bool {
+		return c >= r && value > 1
+	})
+
+	// the mask keeps the existing value at (2, 0)
+	mask := graphblas.NewCSRMatrixFromArray([][]float64{
+		{0, 0, 0},
+		{0, 0, 0},
+		{1, 0, 0},
+	})
+
+	want := graphblas.NewDenseMatrixFromArrayN([][]float64{
+		{0, 5, 0},
+		{0, 2, 7},
+		{9, 0, 4},
+	})
+
+	existing := [][]float64{
+		{9, 0, 0},
+		{0, 0, 0},
+		{9, 9, 0},
+	}
+
+	tests := []struct {
+		name string
+		s    graphblas.Matrix[float64]
+	}{
+		{
+			name: "DenseMatrix",
+			s:    graphblas.NewDenseMatrixFromArrayN(existing),
+		},
+		{
+			name: "CSCMatrix",
+			s:    graphblas.NewCSCMatrixFromArray(existing),
+		},
+		{
+			name: "CSRMatrix",
+			s:    graphblas.NewCSRMatrixFromArray(existing),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			graphblas.Select[float64](context.Background(), graphblas.NewCSRMatrixFromArray(array), mask, op, tt.s)
+			for r := 0; r < 3; r++ {
+				for c := 0; c < 3; c++ {
+					if tt.s.At(r, c) != want.At(r, c) {
+						t.Errorf("%+v Select At(%+v, %+v) = %+v, want %+v", tt.name, r, c, tt.s.At(r, c), want.At(r, c))
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestMatrix_MatrixVectorMultiplyWithSemiring(t *testing.T) {
+	inf := math.Inf(1)
+
+	// a[i, j] the weight of the edge i → j
+	a := graphblas.NewCSRMatrixFromArray([][]float64{
+		{0, 2, 5},
+		{0, 0, 1},
+		{0, 0, 0},
+	})
+	at := graphblas.TransposeToCSR[float64](context.Background(), a)
+
+	distance := graphblas.NewDenseVectorN[float64](3)
+	distance.SetVec(0, 0)
+	distance.SetVec(1, 2)
+	distance.SetVec(2, inf)
+
+	want := []float64{inf, 2, 3}
+
+	tests := []struct {
+		name   string
+		vector graphblas.Vector[float64]
+		want   []float64
+	}{
+		{
+			name:   "DenseVector",
+			vector: graphblas.NewDenseVectorN[float64](3),
+			want:   want,
+		},
+		{
+			name:   "SparseVector",
+			vector: graphblas.NewSparseVector[float64](3),
+			want:   []float64{0, 2, 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			graphblas.MatrixVectorMultiplyWithSemiring[float64](context.Background(), at, distance, graphblas.DefaultSemiringMinPlus[float64](), nil, tt.vector)
+			for i, w := range tt.want {
+				if tt.vector.AtVec(i) != w {
+					t.Errorf("%+v MatrixVectorMultiplyWithSemiring AtVec(%+v) = %+v, want %+v", tt.name, i, tt.vector.AtVec(i), w)
+				}
+			}
+		})
+	}
+}
+
+func TestMatrix_ReduceVectorToScalar_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result := make(chan float64, 1)
+	go func() {
+		result <- graphblas.ReduceVectorToScalar[float64](ctx, graphblas.NewDenseVectorFromArrayN([]float64{1, 2, 3}), nil)
+	}()
+
+	select {
+	case got := <-result:
+		if got != 0 {
+			t.Errorf("ReduceVectorToScalar = %+v, want 0", got)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("ReduceVectorToScalar did not return on a cancelled context")
+	}
+}
+
+func TestMatrix_MatrixMatrixMultiply_Storage(t *testing.T) {
+	left := [][]float64{
+		{1, 2, 0, 0},
+		{0, 0, 3, 0},
+		{4, 0, 0, 5},
+	}
+	right := [][]float64{
+		{0, 6, 0},
+		{7, 0, 0},
+		{0, 8, 9},
+		{1, 0, 2},
+	}
+
+	// the mask keeps the existing value at (0, 0)
+	mask := graphblas.NewCSRMatrixFromArray([][]float64{
+		{1, 0, 0},
+		{0, 0, 0},
+		{0, 0, 0},
+	})
+
+	mapped := func(t *testing.T, array [][]float64, csc bool) graphblas.Matrix[float64] {
+		path := writeMapped(t, func(f *os.File) error {
+			if csc {
+				return graphblas.WriteMappedCSC[float64](f, graphblas.NewCSCMatrixFromArray(array))
+			}
+			return graphblas.WriteMappedCSR[float64](f, graphblas.NewCSRMatrixFromArray(array))
+		})
+		s, err := graphblas.OpenMappedMatrix[float64](path)
+		if err != nil {
+			t.Fatalf("OpenMappedMatrix error %+v", err)
+		}
+		t.Cleanup(func() { s.Close() })
+		return s
+	}
+
+	storage := []struct {
+		name   string
+		matrix func(t *testing.T, array [][]float64) graphblas.Matrix[float64]
+	}{
+		{
+			name: "CSRMatrix",
+			matrix: func(t *testing.T, array [][]float64) graphblas.Matrix[float64] {
+				return graphblas.NewCSRMatrixFromArray(array)
+			},
+		},
+		{
+			name: "CSCMatrix",
+			matrix: func(t *testing.T, array [][]float64) graphblas.Matrix[float64] {
+				return graphblas.NewCSCMatrixFromArray(array)
+			},
+		},
+		{
+			name: "DenseMatrix",
+			matrix: func(t *testing.T, array [][]float64) graphblas.Matrix[float64] {
+				return graphblas.NewDenseMatrixFromArrayN(array)
+			},
+		},
+		{
+			name: "MappedCSR",
+			matrix: func(t *testing.T, array [][]float64) graphblas.Matrix[float64] {
+				return mapped(t, array, false)
+			},
+		},
+		{
+			name: "MappedCSC",
+			matrix: func(t *testing.T, array [][]float64) graphblas.Matrix[float64] {
+				return mapped(t, array, true)
+			},
+		},
+	}
+
+	// the first argument semiring tells a product from its operands swapped
+	semirings := []struct {
+		name     string
+		semiring binaryop.Semiring[float64]
+		times    func(a, b float64) float64
+	}{
+		{
+			name:     "PlusTimes",
+			semiring: graphblas.DefaultSemiringPlusTimes[float64](),
+			times:    func(a, b float64) float64 { return a * b },
+		},
+		{
+			name:     "PlusFirst",
+			semiring: binaryop.NewSemiring(graphblas.DefaultMonoIDAddition[float64](), binaryop.FirstArgument[float64]()),
+			times:    func(a, b float64) float64 { return a },
+		},
+	}
+
+	// every element of a dense operand is stored, so zeros take part in the products
+	for _, semiring := range semirings {
+		for _, s := range storage {
+			for _, m := range storage {
+				want := [3][3]float64{{9}}
+				for r := 0; r < 3; r++ {
+					for c := 0; c < 3; c++ {
+						if mask.Element(r, c) {
+							continue
+						}
+						for k := 0; k < 4; k++ {
+							if (left[r][k] != 0 || s.name == "DenseMatrix") && (right[k][c] != 0 || m.name == "DenseMatrix") {
+								want[r][c] += semiring.times(left[r][k], right[k][c])
+							}
+						}
+					}
+				}
+
+				for _, result := range storage[:3] {
+					name := semiring.name + " " + s.name + " " + m.name + " " + result.name
+					t.Run(name, func(t *testing.T) {
+						got := result.matrix(t, [][]float64{{9, 9, 9}, {9, 9, 9}, {9, 9, 9}})
+						graphblas.MatrixMatrixMultiplyWithSemiring[float64](context.Background(), s.matrix(t, left), m.matrix(t, right), semiring.semiring, mask, got)
+						for r := 0; r < 3; r++ {
+							for c := 0; c < 3; c++ {
+								if got.At(r, c) != want[r][c] {
+									t.Errorf("%+v MatrixMatrixMultiply At(%+v, %+v) = %+v, want %+v", name, r, c, got.At(r, c), want[r][c])
+								}
+							}
+						}
+					})
+				}
+			}
+		}
+	}
+}
+
+func TestMatrix_MatrixVectorMultiply_Mapped(t *testing.T) {
+	n := 100000
+	rows := make([]int, 0, 4*n)
+	cols := make([]int, 0, 4*n)
+	values := make([]float64, 0, 4*n)
+	for r := 0; r < n; r++ {
+		for i := 1; i <= 4; i++ {
+			rows = append(rows, r)
+			cols = append(cols, (r+i)%n)
+			values = append(values, 1)
+		}
+	}
+
+	csr := graphblas.NewCSRMatrixFromTuples(n, n, rows, cols, values, nil)
+	csc := graphblas.NewCSCMatrixFromTuples(n, n, rows, cols, values, nil)
+
+	tests := []struct {
+		name  string
+		write func(f *os.File) error
+	}{
+		{
+			name:  "MappedCSR",
+			write: func(f *os.File) error { return graphblas.WriteMappedCSR[float64](f, csr) },
+		},
+		{
+			name:  "MappedCSC",
+			write: func(f *os.File) error { return graphblas.WriteMappedCSC[float64](f, csc) },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := graphblas.OpenMappedMatrix[float64](writeMapped(t, tt.write))
+			if err != nil {
+				t.Fatalf("%+v OpenMappedMatrix error %+v", tt.name, err)
+			}
+			defer s.Close()
+
+			x := graphblas.NewSparseVector[float64](n)
+			x.SetVec(0, 1)
+			y := graphblas.NewSparseVector[float64](n)
+
+			var before, after runtime.MemStats
+			runtime.ReadMemStats(&before)
+			graphblas.MatrixVectorMultiply[float64](context.Background(), s, x, nil, y)
+			runtime.ReadMemStats(&after)
+
+			// a copy of the 4n indices alone would allocate 32n bytes
+			if allocated := after.TotalAlloc - before.TotalAlloc; allocated > uint64(16*n) {
+				t.Errorf("%+v MatrixVectorMultiply allocated %+v bytes, want under %+v", tt.name, allocated, 16*n)
+			}
+
+			if y.Values() != 4 || y.AtVec(n-1) != 1 {
+				t.Errorf("%+v MatrixVectorMultiply = %+v values, want the 4 in-neighbours of 0", tt.name, y.Values())
+			}
+		})
+	}
+}
@@ -1 +1,234 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
 package shortestpath
+
+import (
+	"context"
+	"log"
+	"math"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/constraints"
+)
+
+// AllPairsOptions the zero value finds only the distances
+type AllPairsOptions struct {
+	// Predecessors records the predecessor matrix so paths can be rebuilt with Path
+	Predecessors bool
+
+	// BatchSize the number of sources AllPairs relaxes together, defaults to 32
+	BatchSize int
+}
+
+func (s AllPairsOptions) withDefaults() AllPairsOptions {
+	if s.BatchSize == 0 {
+		s.BatchSize = 32
+	}
+	return s
+}
+
+// Paths the shortest paths between every pair of vertices
+type Paths struct {
+	// Distance[i, j] the length of the shortest path i → j, +Inf when j can not be reached from i
+	Distance *graphblas.DenseMatrixNumber[float64]
+
+	// Predecessor[i, j] the vertex before j on the shortest path i → j, -1 when there is none,
+	// nil unless the predecessors were requested
+	Predecessor *graphblas.DenseMatrixNumber[int]
+}
+
+// Path the vertices of the shortest path i → j including both ends, nil when j can not be reached from i
+func (s *Paths) Path(i, j int) []int {
+	if s.Predecessor == nil {
+		log.Panicf("Can not rebuild a path without the predecessors")
+	}
+
+	if math.IsInf(s.Distance.At(i, j), 1) {
+		return nil
+	}
+
+	path := []int{j}
+	for j != i {
+		j = s.Predecessor.At(i, j)
+		path = append(path, j)
+	}
+
+	for l, r := 0, len(path)-1; l < r; l, r = l+1, r-1 {
+		path[l], path[r] = path[r], path[l]
+	}
+
+	return path
+}
+
+// FloydWarshall the shortest paths between every pair of vertices of the adjacency matrix a, where a[i, j] is the
+// weight of the edge i → j and weights may be negative, suited to dense graphs
+//
+// the distances start as a copy of a in a DenseMatrix and are updated in place through each intermediate vertex k
+//
+//	D[i, j] = min(D[i, j], D[i, k] + D[k, j])
+//
+// a negative distance from a vertex to itself means a negative cycle and ErrNegativeCycle is returned
+func FloydWarshall[T constraints.Number](ctx context.Context, a graphblas.Matrix[T], options AllPairsOptions) (*Paths, error) {
+	n := a.Rows()
+	paths := newPaths(n, options.Predecessors)
+
+	for iterator := a.Enumerate(); iterator.HasNext(); {
+		r, c, v := iterator.Next()
+		if !graphblas.IsZero(v) && float64(v) < paths.Distance.At(r, c) {
+			paths.Distance.Set(r, c, float64(v))
+			if paths.Predecessor != nil {
+				paths.Predecessor.Set(r, c, r)
+			}
+		}
+	}
+
+	for k := 0; k < n; k++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		for i := 0; i < n; i++ {
+			dik := paths.Distance.At(i, k)
+			if math.IsInf(dik, 1) {
+				continue
+			}
+
+			for j := 0; j < n; j++ {
+				if d := dik + paths.Distance.At(k, j); d < paths.Distance.At(i, j) {
+					paths.Distance.Set(i, j, d)
+					if paths.Predecessor != nil {
+						paths.Predecessor.Set(i, j, paths.Predecessor.At(k, j))
+					}
+				}
+			}
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		if paths.Distance.At(i, i) < 0 {
+			return nil, ErrNegativeCycle
+		}
+	}
+
+	return paths, nil
+}
+
+// AllPairs the shortest paths between every pair of vertices of the adjacency matrix a, where a[i, j] is the
+// weight of the edge i → j and weights may be negative, suited to sparse graphs
+//
+// the sources are searched in batches by Bellman-Ford, each round relaxing every edge for the whole batch with
+// the min-plus mxm D min.+ a, after n - 1 rounds any further improvement means a negative cycle and
+// ErrNegativeCycle is returned, the predecessor is recorded each time a distance improves so zero weight
+// cycles can not leave a loop in the predecessors
+func AllPairs[T constraints.Number](ctx context.Context, a graphblas.Matrix[T], options AllPairsOptions) (*Paths, error) {
+	options = options.withDefaults()
+	n := a.Rows()
+	paths := newPaths(n, options.Predecessors)
+
+	g := graphblas.Structure[T, float64](ctx, a, graphblas.StructureOptions{})
+	at := graphblas.Structure[T, float64](ctx, a, graphblas.StructureOptions{Transpose: true})
+	minPlus := graphblas.DefaultSemiringMinPlus[float64]()
+
+	for start := 0; start < n; start += options.BatchSize {
+		end := start + options.BatchSize
+		if end > n {
+			end = n
+		}
+		k := end - start
+
+		// row i of the batch holds the distances from start + i
+		distance := graphblas.NewDenseMatrixN[float64](k, n)
+		for i := 0; i < k; i++ {
+			for v := 0; v < n; v++ {
+				distance.Set(i, v, math.Inf(1))
+			}
+			distance.Set(i, start+i, 0)
+		}
+
+		request := graphblas.NewDenseMatrixN[float64](k, n)
+		improvements := []int{}
+		for round := 0; ; round++ {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			graphblas.MatrixMatrixMultiplyWithSemiring[float64](ctx, distance, g, minPlus, nil, request)
+
+			improvements = improvements[:0]
+			for i := 0; i < k; i++ {
+				for v := 0; v < n; v++ {
+					if request.At(i, v) < distance.At(i, v) {
+						improvements = append(improvements, i, v)
+					}
+				}
+			}
+
+			if len(improvements) == 0 {
+				break
+			}
+
+			if round == n-1 {
+				return nil, ErrNegativeCycle
+			}
+
+			// the parent is the in-edge the improvement came through, found before any distance of the round is lowered
+			if paths.Predecessor != nil {
+				for l := 0; l < len(improvements); l += 2 {
+					i, v := improvements[l], improvements[l+1]
+					d := request.At(i, v)
+					for iterator := at.RowsAt(v).Enumerate(); iterator.HasNext(); {
+						u, _, w := iterator.Next()
+						if distance.At(i, u)+w == d {
+							paths.Predecessor.Set(start+i, v, u)
+							break
+						}
+					}
+				}
+			}
+
+			for l := 0; l < len(improvements); l += 2 {
+				i, v := improvements[l], improvements[l+1]
+				distance.Set(i, v, request.At(i, v))
+			}
+		}
+
+		for i := 0; i < k; i++ {
+			for v := 0; v < n; v++ {
+				paths.Distance.Set(start+i, v, distance.At(i, v))
+			}
+		}
+	}
+
+	return paths, nil
+}
+
+// newPaths returns the distances, +Inf but for the diagonal, and when requested the predecessors, -1 but for the diagonal
+func newPaths(n int, predecessors bool) *Paths {
+	paths := &Paths{
+		Distance: graphblas.NewDenseMatrixN[float64](n, n),
+	}
+
+	if predecessors {
+		paths.Predecessor = graphblas.NewDenseMatrixN[int](n, n)
+	}
+
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if i != j {
+				paths.Distance.Set(i, j, math.Inf(1))
+			}
+			if predecessors {
+				if i == j {
+					paths.Predecessor.Set(i, j, i)
+				} else {
+					paths.Predecessor.Set(i, j, -1)
+				}
+			}
+		}
+	}
+
+	return paths
+}
@@ -0,0 +1,165 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package shortestpath_test
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/rossmerr/graphblas"
+	shortestpath "github.com/rossmerr/graphblas/shortestPath"
+)
+
+func TestAllPairs(t *testing.T) {
+	want := [][]float64{
+		{0, 1, 2, 3, 4, 5},
+		{3, 0, 1, 2, 3, 4},
+		{2, 3, 0, 1, 2, 3},
+		{1, 2, 3, 0, 1, 2},
+		{5, 2, 3, 4, 0, 1},
+		{4, 1, 2, 3, 4, 0},
+	}
+
+	tests := []struct {
+		name     string
+		allPairs func(ctx context.Context, a graphblas.Matrix[float64], options shortestpath.AllPairsOptions) (*shortestpath.Paths, error)
+		s        graphblas.Matrix[float64]
+		options  shortestpath.AllPairsOptions
+	}{
+		{
+			name:     "FloydWarshall",
+			allPairs: shortestpath.FloydWarshall[float64],
+			s:        graphblas.NewDenseMatrixFromArrayN(weighted),
+			options:  shortestpath.AllPairsOptions{Predecessors: true},
+		},
+		{
+			name:     "AllPairs",
+			allPairs: shortestpath.AllPairs[float64],
+			s:        graphblas.NewCSRMatrixFromArray(weighted),
+			options:  shortestpath.AllPairsOptions{Predecessors: true},
+		},
+		{
+			name:     "AllPairs Batches",
+			allPairs: shortestpath.AllPairs[float64],
+			s:        graphblas.NewCSCMatrixFromArray(weighted),
+			options:  shortestpath.AllPairsOptions{Predecessors: true, BatchSize: 4},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paths, err := tt.allPairs(context.Background(), tt.s, tt.options)
+			if err != nil {
+				t.Fatalf("%+v error %+v", tt.name, err)
+			}
+
+			for i := range want {
+				for j := range want[i] {
+					if paths.Distance.At(i, j) != want[i][j] {
+						t.Errorf("%+v Distance At(%+v, %+v) = %+v, want %+v", tt.name, i, j, paths.Distance.At(i, j), want[i][j])
+					}
+
+					// each path follows edges of the graph and is as long as the distance
+					path := paths.Path(i, j)
+					if path[0] != i || path[len(path)-1] != j {
+						t.Fatalf("%+v Path(%+v, %+v) = %+v", tt.name, i, j, path)
+					}
+					length := 0.0
+					for p := 1; p < len(path); p++ {
+						w := weighted[path[p-1]][path[p]]
+						if w == 0 {
+							t.Fatalf("%+v Path(%+v, %+v) = %+v, no edge %+v → %+v", tt.name, i, j, path, path[p-1], path[p])
+						}
+						length += w
+					}
+					if length != want[i][j] {
+						t.Errorf("%+v Path(%+v, %+v) = %+v, length %+v want %+v", tt.name, i, j, path, length, want[i][j])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestAllPairs_Unreachable(t *testing.T) {
+	inf := math.Inf(1)
+	want := [][]float64{
+		{0, 1, 2, inf},
+		{inf, 0, inf, inf},
+		{inf, -1, 0, inf},
+		{1, 2, 3, 0},
+	}
+
+	for _, allPairs := range []func(ctx context.Context, a graphblas.Matrix[float64], options shortestpath.AllPairsOptions) (*shortestpath.Paths, error){
+		shortestpath.FloydWarshall[float64],
+		shortestpath.AllPairs[float64],
+	} {
+		paths, err := allPairs(context.Background(), graphblas.NewCSRMatrixFromArray(negative), shortestpath.AllPairsOptions{Predecessors: true})
+		if err != nil {
+			t.Fatalf("error %+v", err)
+		}
+
+		for i := range want {
+			for j := range want[i] {
+				if paths.Distance.At(i, j) != want[i][j] {
+					t.Errorf("Distance At(%+v, %+v) = %+v, want %+v", i, j, paths.Distance.At(i, j), want[i][j])
+				}
+			}
+		}
+
+		if path := paths.Path(1, 0); path != nil {
+			t.Errorf("Path(1, 0) = %+v, want nil", path)
+		}
+
+		if path := paths.Path(3, 1); len(path) != 4 || path[2] != 2 {
+			t.Errorf("Path(3, 1) = %+v, want [3 0 2 1]", path)
+		}
+	}
+}
+
+func TestAllPairs_ZeroWeightCycle(t *testing.T) {
+	// 0 → 1 → 0 weighs nothing, so both edges tie with the shortest paths from 2
+	g := graphblas.NewCSRMatrixFromArray([][]float64{
+		{0, 1, 0},
+		{-1, 0, 0},
+		{0, 1, 0},
+	})
+
+	for _, allPairs := range []func(ctx context.Context, a graphblas.Matrix[float64], options shortestpath.AllPairsOptions) (*shortestpath.Paths, error){
+		shortestpath.FloydWarshall[float64],
+		shortestpath.AllPairs[float64],
+	} {
+		paths, err := allPairs(context.Background(), g, shortestpath.AllPairsOptions{Predecessors: true})
+		if err != nil {
+			t.Fatalf("error %+v", err)
+		}
+
+		if path := paths.Path(2, 1); !reflect.DeepEqual(path, []int{2, 1}) {
+			t.Errorf("Path(2, 1) = %+v, want [2 1]", path)
+		}
+
+		if path := paths.Path(2, 0); !reflect.DeepEqual(path, []int{2, 1, 0}) {
+			t.Errorf("Path(2, 0) = %+v, want [2 1 0]", path)
+		}
+	}
+}
+
+func TestAllPairs_NegativeCycle(t *testing.T) {
+	g := graphblas.NewCSRMatrixFromArray([][]float64{
+		{0, 1, 0},
+		{0, 0, -2},
+		{0, 1, 0},
+	})
+
+	if _, err := shortestpath.FloydWarshall[float64](context.Background(), g, shortestpath.AllPairsOptions{}); err != shortestpath.ErrNegativeCycle {
+		t.Errorf("FloydWarshall error = %+v, want %+v", err, shortestpath.ErrNegativeCycle)
+	}
+
+	if _, err := shortestpath.AllPairs[float64](context.Background(), g, shortestpath.AllPairsOptions{}); err != shortestpath.ErrNegativeCycle {
+		t.Errorf("AllPairs error = %+v, want %+v", err, shortestpath.ErrNegativeCycle)
+	}
+}
@@ -1 +1,301 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+// Package shortestpath finds the shortest paths through a weighted graph over the min-plus semiring
 package shortestpath
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/constraints"
+	"github.com/rossmerr/graphblas/unaryop"
+)
+
+var (
+	// ErrNegativeCycle a cycle of negative weight is reachable so some distances are unbounded
+	ErrNegativeCycle = errors.New("shortestpath: negative cycle")
+
+	// ErrNegativeWeight the algorithm requires non-negative edge weights
+	ErrNegativeWeight = errors.New("shortestpath: negative edge weight")
+)
+
+// SingleSourceOptions the zero value searches the whole graph
+type SingleSourceOptions struct {
+	// Delta the bucket width of delta-stepping, defaults to the mean edge weight
+	Delta float64
+
+	// Target the vertex to stop at when StopAtTarget is set
+	Target int
+
+	// StopAtTarget stops delta-stepping once the distance to Target is final, the distances of vertices further
+	// away than the target are left as found so far, Bellman-Ford does not support it as with negative weights
+	// no distance is final until the search completes
+	StopAtTarget bool
+}
+
+// target panics when StopAtTarget is set with a Target outside the n vertices
+func (s SingleSourceOptions) target(n int) {
+	if s.StopAtTarget && (s.Target < 0 || s.Target >= n) {
+		log.Panicf("Target '%+v' is invalid", s.Target)
+	}
+}
+
+// BellmanFord the shortest distance from source to every vertex of the adjacency matrix a, where a[i, j] is the
+// weight of the edge i → j and weights may be negative, along with the parent of each vertex on its shortest path
+//
+// each round relaxes every edge at once with the min-plus mxv aᵀ min.+ d, after n - 1 rounds any further
+// improvement means a negative cycle is reachable and ErrNegativeCycle is returned, unreachable vertices have
+// a distance of +Inf and a parent of -1, the parent of the source is itself, StopAtTarget is not supported and panics
+func BellmanFord[T constraints.Number](ctx context.Context, a graphblas.Matrix[T], source int, options SingleSourceOptions) (graphblas.Vector[float64], graphblas.Vector[int], error) {
+	n := a.Rows()
+	if source < 0 || source >= n {
+		log.Panicf("Source '%+v' is invalid", source)
+	}
+
+	if options.StopAtTarget {
+		log.Panicf("BellmanFord can not stop at a target")
+	}
+
+	at := graphblas.Structure[T, float64](ctx, a, graphblas.StructureOptions{Transpose: true})
+	distance, parent := initialise(n, source)
+
+	var request graphblas.Vector[float64] = graphblas.NewDenseVectorN[float64](n)
+	minPlus := graphblas.DefaultSemiringMinPlus[float64]()
+
+	for round := 0; ; round++ {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+
+		graphblas.MatrixVectorMultiplyWithSemiring[float64](ctx, at, distance, minPlus, nil, request)
+
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+
+		improved := relax(at, distance, request, distance, parent, nil)
+		if len(improved) == 0 {
+			break
+		}
+
+		if round == n-1 {
+			return nil, nil, ErrNegativeCycle
+		}
+	}
+
+	return distance, parent, nil
+}
+
+// DeltaStepping the shortest distance from source to every vertex of the adjacency matrix a, where a[i, j] is the
+// non-negative weight of the edge i → j, along with the parent of each vertex on its shortest path
+//
+// Select splits the edges into light edges no heavier than delta and heavy ones, the vertices are settled a bucket
+// of width delta at a time, the light edges are relaxed until the bucket empties and then the heavy edges once,
+// each relaxation is a min-plus mxv masked by the vertices already settled, unreachable vertices have a distance
+// of +Inf and a parent of -1, the parent of the source is itself
+//
+// each bucket lists the vertices whose distance fell into it, a vertex whose distance has since moved to a lower
+// bucket is skipped when its old bucket is reached
+func DeltaStepping[T constraints.Number](ctx context.Context, a graphblas.Matrix[T], source int, options SingleSourceOptions) (graphblas.Vector[float64], graphblas.Vector[int], error) {
+	n := a.Rows()
+	if source < 0 || source >= n {
+		log.Panicf("Source '%+v' is invalid", source)
+	}
+	options.target(n)
+
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	at := graphblas.Structure[T, float64](ctx, a, graphblas.StructureOptions{Transpose: true})
+	if negative(at) {
+		return nil, nil, ErrNegativeWeight
+	}
+
+	delta := options.Delta
+	if delta <= 0 {
+		delta = 1
+		if at.Values() > 0 {
+			delta = graphblas.ReduceMatrixToScalar[float64](ctx, at, nil) / float64(at.Values())
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	light := graphblas.NewCSRMatrix[float64](n, n)
+	graphblas.Select[float64](ctx, at, nil, unaryop.NewSelectOp(func(r, c int, value float64) bool {
+		return value <= delta
+	}), light)
+
+	heavy := graphblas.NewCSRMatrix[float64](n, n)
+	graphblas.Select[float64](ctx, at, nil, unaryop.NewSelectOp(func(r, c int, value float64) bool {
+		return value > delta
+	}), heavy)
+
+	distance, parent := initialise(n, source)
+
+	// settled masks the vertices whose distance is final
+	settled := graphblas.NewSparseVector[float64](n)
+	minPlus := graphblas.DefaultSemiringMinPlus[float64]()
+	var request graphblas.Vector[float64] = graphblas.NewDenseVectorN[float64](n)
+
+	buckets := map[int][]int{0: {source}}
+	index := func(v int) int {
+		return int(math.Floor(distance.AtVec(v) / delta))
+	}
+
+	// queued marks the members of the bucket being settled so a vertex listed twice is only taken once
+	queued := make([]bool, n)
+
+	for len(buckets) > 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+
+		bucket := math.MaxInt
+		for b := range buckets {
+			if b < bucket {
+				bucket = b
+			}
+		}
+
+		members := []int{}
+		for _, v := range buckets[bucket] {
+			if !queued[v] && settled.AtVec(v) == 0 && index(v) == bucket {
+				queued[v] = true
+				members = append(members, v)
+			}
+		}
+		delete(buckets, bucket)
+
+		if len(members) == 0 {
+			continue
+		}
+
+		// an improved vertex either rejoins the bucket being settled or is listed in a later one
+		requeue := func(improved []int) []int {
+			again := []int{}
+			for _, v := range improved {
+				if b := index(v); b == bucket {
+					again = append(again, v)
+				} else {
+					buckets[b] = append(buckets[b], v)
+				}
+			}
+			return again
+		}
+
+		removed := []int{}
+		frontier := graphblas.NewDenseVectorN[float64](n)
+		for len(members) > 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, nil, err
+			}
+
+			fill(frontier, math.Inf(1))
+			for _, v := range members {
+				frontier.SetVec(v, distance.AtVec(v))
+			}
+			removed = append(removed, members...)
+
+			graphblas.MatrixVectorMultiplyWithSemiring[float64](ctx, light, frontier, minPlus, settled, request)
+			members = requeue(relax(light, frontier, request, distance, parent, settled))
+		}
+
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+
+		fill(frontier, math.Inf(1))
+		for _, v := range removed {
+			frontier.SetVec(v, distance.AtVec(v))
+		}
+
+		graphblas.MatrixVectorMultiplyWithSemiring[float64](ctx, heavy, frontier, minPlus, settled, request)
+		buckets[bucket] = requeue(relax(heavy, frontier, request, distance, parent, settled))
+
+		// a vertex removed from the bucket may return to it until the bucket empties
+		for _, v := range removed {
+			settled.SetVec(v, 1)
+			queued[v] = false
+		}
+
+		if options.StopAtTarget && settled.AtVec(options.Target) != 0 {
+			break
+		}
+	}
+
+	return distance, parent, nil
+}
+
+// initialise returns the distances, +Inf but for the source, and the parents, -1 but for the source
+func initialise(n, source int) (graphblas.Vector[float64], graphblas.Vector[int]) {
+	distance := graphblas.NewDenseVectorN[float64](n)
+	fill(distance, math.Inf(1))
+	distance.SetVec(source, 0)
+
+	parent := graphblas.NewDenseVectorN[int](n)
+	for v := 0; v < n; v++ {
+		parent.SetVec(v, -1)
+	}
+	parent.SetVec(source, source)
+
+	return distance, parent
+}
+
+// relax lowers the distance of each vertex the request improves on and returns them, the parent is the first
+// in-edge of at the request came through from the frontier, vertices the mask excludes are skipped
+func relax(at graphblas.Matrix[float64], frontier, request, distance graphblas.Vector[float64], parent graphblas.Vector[int], mask graphblas.Mask) []int {
+	improved := []int{}
+	for v := 0; v < request.Length(); v++ {
+		if mask != nil && mask.Element(v, 0) {
+			continue
+		}
+
+		d := request.AtVec(v)
+		if d >= distance.AtVec(v) {
+			continue
+		}
+
+		for iterator := at.RowsAt(v).Enumerate(); iterator.HasNext(); {
+			u, _, w := iterator.Next()
+			if frontier.AtVec(u)+w == d {
+				parent.SetVec(v, u)
+				break
+			}
+		}
+
+		improved = append(improved, v)
+	}
+
+	// the frontier may be the distances so they are only lowered once every parent is found
+	for _, v := range improved {
+		distance.SetVec(v, request.AtVec(v))
+	}
+
+	return improved
+}
+
+func fill(s graphblas.Vector[float64], value float64) {
+	for i := 0; i < s.Length(); i++ {
+		s.SetVec(i, value)
+	}
+}
+
+// negative whether any weight of a is negative
+func negative(a graphblas.Matrix[float64]) bool {
+	for iterator := a.Enumerate(); iterator.HasNext(); {
+		if _, _, v := iterator.Next(); v < 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,171 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package shortestpath_test
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/internal/cancel"
+	shortestpath "github.com/rossmerr/graphblas/shortestPath"
+)
+
+var weighted = [][]float64{
+	{0, 1, 4, 0, 0, 0},
+	{0, 0, 1, 2, 0, 0},
+	{0, 0, 0, 1, 3, 0},
+	{1, 0, 0, 0, 1, 2},
+	{0, 0, 0, 0, 0, 1},
+	{0, 1, 0, 0, 0, 0},
+}
+
+// vertex 3 can not be reached
+var negative = [][]float64{
+	{0, 4, 2, 0},
+	{0, 0, 0, 0},
+	{0, -1, 0, 0},
+	{1, 0, 0, 0},
+}
+
+func TestBellmanFord(t *testing.T) {
+	inf := math.Inf(1)
+
+	tests := []struct {
+		name     string
+		s        graphblas.Matrix[float64]
+		distance []float64
+		parent   []int
+	}{
+		{
+			name:     "CSRMatrix",
+			s:        graphblas.NewCSRMatrixFromArray(weighted),
+			distance: []float64{0, 1, 2, 3, 4, 5},
+			parent:   []int{0, 0, 1, 1, 3, 3},
+		},
+		{
+			name:     "DenseMatrix",
+			s:        graphblas.NewDenseMatrixFromArrayN(weighted),
+			distance: []float64{0, 1, 2, 3, 4, 5},
+			parent:   []int{0, 0, 1, 1, 3, 3},
+		},
+		{
+			name:     "Negative",
+			s:        graphblas.NewCSCMatrixFromArray(negative),
+			distance: []float64{0, 1, 2, inf},
+			parent:   []int{0, 2, 0, -1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			distance, parent, err := shortestpath.BellmanFord(context.Background(), tt.s, 0, shortestpath.SingleSourceOptions{})
+			if err != nil {
+				t.Fatalf("%+v BellmanFord error %+v", tt.name, err)
+			}
+
+			for i := range tt.distance {
+				if distance.AtVec(i) != tt.distance[i] {
+					t.Errorf("%+v BellmanFord distance AtVec(%+v) = %+v, want %+v", tt.name, i, distance.AtVec(i), tt.distance[i])
+				}
+				if parent.AtVec(i) != tt.parent[i] {
+					t.Errorf("%+v BellmanFord parent AtVec(%+v) = %+v, want %+v", tt.name, i, parent.AtVec(i), tt.parent[i])
+				}
+			}
+		})
+	}
+}
+
+func TestBellmanFord_NegativeCycle(t *testing.T) {
+	g := graphblas.NewCSRMatrixFromArray([][]float64{
+		{0, 1, 0},
+		{0, 0, -2},
+		{0, 1, 0},
+	})
+
+	if _, _, err := shortestpath.BellmanFord[float64](context.Background(), g, 0, shortestpath.SingleSourceOptions{}); err != shortestpath.ErrNegativeCycle {
+		t.Errorf("BellmanFord error = %+v, want %+v", err, shortestpath.ErrNegativeCycle)
+	}
+}
+
+func TestDeltaStepping(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       graphblas.Matrix[float64]
+		options shortestpath.SingleSourceOptions
+	}{
+		{
+			name: "Mean",
+			s:    graphblas.NewCSRMatrixFromArray(weighted),
+		},
+		{
+			name:    "Light",
+			s:       graphblas.NewDenseMatrixFromArrayN(weighted),
+			options: shortestpath.SingleSourceOptions{Delta: 10},
+		},
+		{
+			name:    "Heavy",
+			s:       graphblas.NewCSCMatrixFromArray(weighted),
+			options: shortestpath.SingleSourceOptions{Delta: 0.5},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			distance, parent, err := shortestpath.DeltaStepping(context.Background(), tt.s, 0, tt.options)
+			if err != nil {
+				t.Fatalf("%+v DeltaStepping error %+v", tt.name, err)
+			}
+
+			wantDistance := []float64{0, 1, 2, 3, 4, 5}
+			wantParent := []int{0, 0, 1, 1, 3, 3}
+			for i := range wantDistance {
+				if distance.AtVec(i) != wantDistance[i] {
+					t.Errorf("%+v DeltaStepping distance AtVec(%+v) = %+v, want %+v", tt.name, i, distance.AtVec(i), wantDistance[i])
+				}
+				if parent.AtVec(i) != wantParent[i] {
+					t.Errorf("%+v DeltaStepping parent AtVec(%+v) = %+v, want %+v", tt.name, i, parent.AtVec(i), wantParent[i])
+				}
+			}
+		})
+	}
+}
+
+func TestDeltaStepping_Target(t *testing.T) {
+	g := graphblas.NewCSRMatrixFromArray(weighted)
+
+	distance, parent, err := shortestpath.DeltaStepping[float64](context.Background(), g, 0, shortestpath.SingleSourceOptions{Delta: 1, Target: 2, StopAtTarget: true})
+	if err != nil {
+		t.Fatalf("DeltaStepping error %+v", err)
+	}
+
+	if distance.AtVec(2) != 2 || parent.AtVec(2) != 1 {
+		t.Errorf("DeltaStepping distance %+v parent %+v, want 2 from 1", distance.AtVec(2), parent.AtVec(2))
+	}
+
+	// the search stopped before reaching the far side of the graph
+	if !math.IsInf(distance.AtVec(5), 1) {
+		t.Errorf("DeltaStepping distance AtVec(5) = %+v, want +Inf", distance.AtVec(5))
+	}
+}
+
+func TestDeltaStepping_NegativeWeight(t *testing.T) {
+	g := graphblas.NewCSRMatrixFromArray(negative)
+
+	if _, _, err := shortestpath.DeltaStepping[float64](context.Background(), g, 0, shortestpath.SingleSourceOptions{}); err != shortestpath.ErrNegativeWeight {
+		t.Errorf("DeltaStepping error = %+v, want %+v", err, shortestpath.ErrNegativeWeight)
+	}
+}
+
+func TestDeltaStepping_Cancel(t *testing.T) {
+	err := cancel.Every(time.Second, func(ctx context.Context) error {
+		_, _, err := shortestpath.DeltaStepping[float64](ctx, graphblas.NewCSRMatrixFromArray(weighted), 0, shortestpath.SingleSourceOptions{})
+		return err
+	})
+	if err != nil {
+		t.Errorf("DeltaStepping %+v", err)
+	}
+}
@@ -1 +1,246 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
 package shortestpath
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/unaryop"
+)
+
+// Contacts a contact network as time-indexed sparse matrices, Edges[k][i, j] is the positive duration of
+// the contact i → j departing at Departures[k]
+type Contacts struct {
+	Departures []float64
+	Edges      []graphblas.Matrix[float64]
+}
+
+// Window the times journeys may depart and arrive within, a nil End leaves the window open
+type Window struct {
+	Start float64
+	End   *float64
+}
+
+func (s Window) end() float64 {
+	if s.End == nil {
+		return math.Inf(1)
+	}
+	return *s.End
+}
+
+// snapshot the contacts departing at one time with their transpose
+type snapshot struct {
+	departure float64
+	edges     *graphblas.CSRMatrix[float64]
+	transpose *graphblas.CSRMatrix[float64]
+}
+
+// snapshots returns the number of vertices and the contacts within the window ordered by departure time, every
+// matrix of Edges must be square and of the same size
+func (s *Contacts) snapshots(ctx context.Context, window Window) (int, []snapshot, error) {
+	if len(s.Departures) != len(s.Edges) {
+		return 0, nil, fmt.Errorf("shortestpath: can not pair %d departures with %d edges", len(s.Departures), len(s.Edges))
+	}
+
+	if len(s.Edges) == 0 {
+		return 0, nil, errors.New("shortestpath: no contacts")
+	}
+
+	n := s.Edges[0].Rows()
+	snapshots := []snapshot{}
+	for k, edges := range s.Edges {
+		if edges.Rows() != n || edges.Columns() != n {
+			return 0, nil, fmt.Errorf("shortestpath: edges %d are %dx%d, want %dx%d", k, edges.Rows(), edges.Columns(), n, n)
+		}
+
+		if t := s.Departures[k]; t >= window.Start && t <= window.end() {
+			g := graphblas.Structure[float64, float64](ctx, edges, graphblas.StructureOptions{})
+			gt := graphblas.Structure[float64, float64](ctx, edges, graphblas.StructureOptions{Transpose: true})
+			snapshots = append(snapshots, snapshot{departure: t, edges: g, transpose: gt})
+		}
+	}
+
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].departure < snapshots[j].departure
+	})
+
+	return n, snapshots, ctx.Err()
+}
+
+// EarliestArrival the earliest time each vertex can be reached leaving source no earlier than the start of the
+// window and arriving by its end, along with the vertex each is reached from, waiting at a vertex is allowed
+//
+// the contacts are taken in departure order, the vertices already reached by the departure time t are given the
+// value t and the arrivals through the contacts are the min-plus mxv Eᵀ min.+ x, unreachable vertices have an
+// arrival of +Inf and a parent of -1, the parent of the source is itself
+func EarliestArrival(ctx context.Context, contacts *Contacts, source int, window Window) (graphblas.Vector[float64], graphblas.Vector[int], error) {
+	n, snapshots, err := contacts.snapshots(ctx, window)
+	if err != nil {
+		return nil, nil, err
+	}
+	return earliestArrival(ctx, n, snapshots, source, window.Start, window.end())
+}
+
+func earliestArrival(ctx context.Context, n int, snapshots []snapshot, source int, start, end float64) (graphblas.Vector[float64], graphblas.Vector[int], error) {
+	if source < 0 || source >= n {
+		log.Panicf("Source '%+v' is invalid", source)
+	}
+
+	arrival, parent := initialise(n, source)
+	arrival.SetVec(source, start)
+
+	minPlus := graphblas.DefaultSemiringMinPlus[float64]()
+	x := graphblas.NewDenseVectorN[float64](n)
+	var request graphblas.Vector[float64] = graphblas.NewDenseVectorN[float64](n)
+
+	for _, snapshot := range snapshots {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+
+		t := snapshot.departure
+		if t < start {
+			continue
+		}
+
+		for v := 0; v < n; v++ {
+			if arrival.AtVec(v) <= t {
+				x.SetVec(v, t)
+			} else {
+				x.SetVec(v, math.Inf(1))
+			}
+		}
+
+		graphblas.MatrixVectorMultiplyWithSemiring[float64](ctx, snapshot.transpose, x, minPlus, nil, request)
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+
+		for v := 0; v < n; v++ {
+			d := request.AtVec(v)
+			if d > end || d >= arrival.AtVec(v) {
+				continue
+			}
+
+			arrival.SetVec(v, d)
+			for iterator := snapshot.transpose.RowsAt(v).Enumerate(); iterator.HasNext(); {
+				u, _, w := iterator.Next()
+				if x.AtVec(u)+w == d {
+					parent.SetVec(v, u)
+					break
+				}
+			}
+		}
+	}
+
+	return arrival, parent, nil
+}
+
+// LatestDeparture the latest time each vertex can be left within the window and still reach target by the end
+// of the window, along with the next vertex of that journey
+//
+// the contacts are taken in reverse departure order, Select keeps the contacts i → j departing at t that arrive
+// before the latest departure from j, unreachable vertices have a departure of -Inf and a next vertex of -1,
+// the target departs at the end of the window and is its own next vertex
+func LatestDeparture(ctx context.Context, contacts *Contacts, target int, window Window) (graphblas.Vector[float64], graphblas.Vector[int], error) {
+	n, snapshots, err := contacts.snapshots(ctx, window)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if target < 0 || target >= n {
+		log.Panicf("Target '%+v' is invalid", target)
+	}
+
+	departure, next := initialise(n, target)
+	for v := 0; v < n; v++ {
+		departure.SetVec(v, math.Inf(-1))
+	}
+	departure.SetVec(target, window.end())
+
+	for k := len(snapshots) - 1; k >= 0; k-- {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+
+		t := snapshots[k].departure
+		kept := graphblas.NewCSRMatrix[float64](n, n)
+		graphblas.Select[float64](ctx, snapshots[k].edges, nil, unaryop.NewSelectOp(func(r, c int, value float64) bool {
+			return t+value <= departure.AtVec(c)
+		}), kept)
+
+		// the departures are raised once the whole snapshot is chosen so contacts at t do not chain
+		later := map[int]int{}
+		for iterator := kept.Enumerate(); iterator.HasNext(); {
+			u, v, _ := iterator.Next()
+			if _, ok := later[u]; !ok && t > departure.AtVec(u) {
+				later[u] = v
+			}
+		}
+
+		for u, v := range later {
+			departure.SetVec(u, t)
+			next.SetVec(u, v)
+		}
+	}
+
+	return departure, next, nil
+}
+
+// Fastest the shortest journey time from source to each vertex within the window, the least arrival less the
+// departure over every time a contact leaves source, along with the vertex each is reached from on that journey
+//
+// each vertex keeps the parent of its own fastest journey and the journeys of two vertices may leave source at
+// different times, so following the parents back from a vertex can mix journeys and need not reach source by
+// contacts that connect in time, EarliestArrival from the departure of the journey to a vertex gives its route
+//
+// unreachable vertices have a duration of +Inf and a parent of -1, the source has a duration of 0 and is its own parent
+func Fastest(ctx context.Context, contacts *Contacts, source int, window Window) (graphblas.Vector[float64], graphblas.Vector[int], error) {
+	n, snapshots, err := contacts.snapshots(ctx, window)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if source < 0 || source >= n {
+		log.Panicf("Source '%+v' is invalid", source)
+	}
+
+	duration, parent := initialise(n, source)
+	duration.SetVec(source, 0)
+
+	last := math.NaN()
+	for _, snapshot := range snapshots {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+
+		start := snapshot.departure
+		if start == last || snapshot.edges.RowsAt(source).Values() == 0 {
+			continue
+		}
+		last = start
+
+		arrival, from, err := earliestArrival(ctx, n, snapshots, source, start, window.end())
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for v := 0; v < n; v++ {
+			if d := arrival.AtVec(v) - start; v != source && d < duration.AtVec(v) {
+				duration.SetVec(v, d)
+				parent.SetVec(v, from.AtVec(v))
+			}
+		}
+	}
+
+	return duration, parent, nil
+}
@@ -0,0 +1,204 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package shortestpath_test
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/internal/cancel"
+	shortestpath "github.com/rossmerr/graphblas/shortestPath"
+)
+
+func contact(i, j int, duration float64) graphblas.Matrix[float64] {
+	edges := graphblas.NewCSRMatrix[float64](4, 4)
+	edges.Set(i, j, duration)
+	return edges
+}
+
+// the contacts are listed out of departure order, vertex 3 is only reached before anyone arrives at 2
+var contacts = &shortestpath.Contacts{
+	Departures: []float64{1, 3, 2, 5, 6, 0},
+	Edges: []graphblas.Matrix[float64]{
+		contact(0, 1, 1),
+		contact(1, 2, 1),
+		contact(0, 2, 5),
+		contact(0, 1, 1),
+		contact(1, 2, 1),
+		contact(2, 3, 1),
+	},
+}
+
+func TestEarliestArrival(t *testing.T) {
+	inf := math.Inf(1)
+	zero, end := 0.0, 3.5
+
+	tests := []struct {
+		name    string
+		window  shortestpath.Window
+		arrival []float64
+		parent  []int
+	}{
+		{
+			name:    "Open",
+			arrival: []float64{0, 2, 4, inf},
+			parent:  []int{0, 0, 1, -1},
+		},
+		{
+			name:    "Start",
+			window:  shortestpath.Window{Start: 2},
+			arrival: []float64{2, 6, 7, inf},
+			parent:  []int{0, 0, 0, -1},
+		},
+		{
+			name:    "End",
+			window:  shortestpath.Window{End: &end},
+			arrival: []float64{0, 2, inf, inf},
+			parent:  []int{0, 0, -1, -1},
+		},
+		{
+			name:    "End Zero",
+			window:  shortestpath.Window{End: &zero},
+			arrival: []float64{0, inf, inf, inf},
+			parent:  []int{0, -1, -1, -1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			arrival, parent, err := shortestpath.EarliestArrival(context.Background(), contacts, 0, tt.window)
+			if err != nil {
+				t.Fatalf("%+v EarliestArrival error %+v", tt.name, err)
+			}
+
+			for i := range tt.arrival {
+				if arrival.AtVec(i) != tt.arrival[i] {
+					t.Errorf("%+v EarliestArrival arrival AtVec(%+v) = %+v, want %+v", tt.name, i, arrival.AtVec(i), tt.arrival[i])
+				}
+				if parent.AtVec(i) != tt.parent[i] {
+					t.Errorf("%+v EarliestArrival parent AtVec(%+v) = %+v, want %+v", tt.name, i, parent.AtVec(i), tt.parent[i])
+				}
+			}
+		})
+	}
+}
+
+func TestLatestDeparture(t *testing.T) {
+	inf := math.Inf(1)
+	end := 6.5
+
+	tests := []struct {
+		name      string
+		window    shortestpath.Window
+		departure []float64
+		next      []int
+	}{
+		{
+			name:      "Open",
+			departure: []float64{5, 6, inf, -inf},
+			next:      []int{1, 2, 2, -1},
+		},
+		{
+			name:      "End",
+			window:    shortestpath.Window{End: &end},
+			departure: []float64{1, 3, 6.5, -inf},
+			next:      []int{1, 2, 2, -1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			departure, next, err := shortestpath.LatestDeparture(context.Background(), contacts, 2, tt.window)
+			if err != nil {
+				t.Fatalf("%+v LatestDeparture error %+v", tt.name, err)
+			}
+
+			for i := range tt.departure {
+				if departure.AtVec(i) != tt.departure[i] {
+					t.Errorf("%+v LatestDeparture departure AtVec(%+v) = %+v, want %+v", tt.name, i, departure.AtVec(i), tt.departure[i])
+				}
+				if next.AtVec(i) != tt.next[i] {
+					t.Errorf("%+v LatestDeparture next AtVec(%+v) = %+v, want %+v", tt.name, i, next.AtVec(i), tt.next[i])
+				}
+			}
+		})
+	}
+}
+
+func TestFastest(t *testing.T) {
+	duration, parent, err := shortestpath.Fastest(context.Background(), contacts, 0, shortestpath.Window{})
+	if err != nil {
+		t.Fatalf("Fastest error %+v", err)
+	}
+
+	wantDuration := []float64{0, 1, 2, math.Inf(1)}
+	wantParent := []int{0, 0, 1, -1}
+	for i := range wantDuration {
+		if duration.AtVec(i) != wantDuration[i] {
+			t.Errorf("Fastest duration AtVec(%+v) = %+v, want %+v", i, duration.AtVec(i), wantDuration[i])
+		}
+		if parent.AtVec(i) != wantParent[i] {
+			t.Errorf("Fastest parent AtVec(%+v) = %+v, want %+v", i, parent.AtVec(i), wantParent[i])
+		}
+	}
+}
+
+func TestFastest_Cancel(t *testing.T) {
+	err := cancel.Every(time.Second, func(ctx context.Context) error {
+		_, _, err := shortestpath.Fastest(ctx, contacts, 0, shortestpath.Window{})
+		return err
+	})
+	if err != nil {
+		t.Errorf("Fastest %+v", err)
+	}
+}
+
+func TestContacts_Error(t *testing.T) {
+	tests := []struct {
+		name     string
+		contacts *shortestpath.Contacts
+	}{
+		{
+			name:     "Empty",
+			contacts: &shortestpath.Contacts{},
+		},
+		{
+			name: "Departures",
+			contacts: &shortestpath.Contacts{
+				Departures: []float64{0, 1},
+				Edges:      []graphblas.Matrix[float64]{contact(0, 1, 1)},
+			},
+		},
+		{
+			name: "Sizes",
+			contacts: &shortestpath.Contacts{
+				Departures: []float64{0, 1},
+				Edges:      []graphblas.Matrix[float64]{contact(0, 1, 1), graphblas.NewCSRMatrix[float64](3, 3)},
+			},
+		},
+		{
+			name: "Square",
+			contacts: &shortestpath.Contacts{
+				Departures: []float64{0},
+				Edges:      []graphblas.Matrix[float64]{graphblas.NewCSRMatrix[float64](4, 3)},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := shortestpath.EarliestArrival(context.Background(), tt.contacts, 0, shortestpath.Window{}); err == nil {
+				t.Errorf("%+v EarliestArrival expected an error", tt.name)
+			}
+			if _, _, err := shortestpath.LatestDeparture(context.Background(), tt.contacts, 0, shortestpath.Window{}); err == nil {
+				t.Errorf("%+v LatestDeparture expected an error", tt.name)
+			}
+			if _, _, err := shortestpath.Fastest(context.Background(), tt.contacts, 0, shortestpath.Window{}); err == nil {
+				t.Errorf("%+v Fastest expected an error", tt.name)
+			}
+		})
+	}
+}
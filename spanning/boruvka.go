@@ -0,0 +1,141 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+// Package spanning finds the minimum spanning forest of a weighted undirected graph
+package spanning
+
+import (
+	"context"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/unaryop"
+)
+
+// Boruvka the minimum spanning forest of the weighted undirected graph with the symmetric adjacency matrix a,
+// returns the edges of the forest in both directions with their weights and the total weight of the forest
+//
+// every round contracts each tree to its root by selecting only the edges between trees, reduces each row to its
+// lightest edge with a min.first mxv and joins each tree to its neighbour along the lightest edge leaving it,
+// equal weights are broken by the lower then the higher vertex of the edge so the forest does not depend on the order of the edges
+func Boruvka(ctx context.Context, a graphblas.Matrix[float64]) (*graphblas.CSRMatrix[float64], float64, error) {
+	n := a.Rows()
+
+	parent := make([]int, n)
+	ones := graphblas.NewDenseVectorN[float64](n)
+	for v := range parent {
+		parent[v] = v
+		ones.SetVec(v, 1)
+	}
+
+	lightest := graphblas.NewDenseVectorN[float64](n)
+	semiring := graphblas.DefaultSemiringMinFirst[float64]()
+
+	between := unaryop.NewSelectOp(func(r, c int, value float64) bool {
+		return parent[r] != parent[c]
+	})
+	minimum := unaryop.NewSelectOp(func(r, c int, value float64) bool {
+		return value == lightest.AtVec(r)
+	})
+
+	rows := []int{}
+	cols := []int{}
+	values := []float64{}
+	total := 0.0
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, 0, err
+		}
+
+		// contract the trees by dropping the edges inside them
+		g := graphblas.NewCSRMatrix[float64](n, n)
+		graphblas.Select[float64](ctx, a, nil, between, g)
+		if g.Values() == 0 {
+			break
+		}
+
+		graphblas.MatrixVectorMultiplyWithSemiring[float64](ctx, g, ones, semiring, nil, lightest)
+
+		candidates := graphblas.NewCSRMatrix[float64](n, n)
+		graphblas.Select[float64](ctx, g, nil, minimum, candidates)
+
+		if err := ctx.Err(); err != nil {
+			return nil, 0, err
+		}
+
+		// the lightest edge leaving each tree
+		best := map[int]edge{}
+		for iterator := candidates.Enumerate(); iterator.HasNext(); {
+			u, v, w := iterator.Next()
+			e := newEdge(u, v, w)
+			if current, ok := best[parent[u]]; !ok || e.less(current) {
+				best[parent[u]] = e
+			}
+		}
+
+		// join the trees, two trees may pick the same edge which is only added once
+		joined := map[edge]bool{}
+		for _, e := range best {
+			if joined[e] {
+				continue
+			}
+			joined[e] = true
+
+			rows = append(rows, e.u, e.v)
+			cols = append(cols, e.v, e.u)
+			values = append(values, e.w, e.w)
+			total += e.w
+		}
+
+		link := make([]int, n)
+		for v := range link {
+			link[v] = v
+		}
+		var find func(v int) int
+		find = func(v int) int {
+			if link[v] != v {
+				link[v] = find(link[v])
+			}
+			return link[v]
+		}
+		for e := range joined {
+			ru, rv := find(parent[e.u]), find(parent[e.v])
+			if ru < rv {
+				link[rv] = ru
+			} else if rv < ru {
+				link[ru] = rv
+			}
+		}
+		for v := range parent {
+			parent[v] = find(parent[v])
+		}
+	}
+
+	return graphblas.NewCSRMatrixFromTuples(n, n, rows, cols, values, nil), total, nil
+}
+
+// edge an undirected edge with u the lower vertex
+type edge struct {
+	u, v int
+	w    float64
+}
+
+func newEdge(u, v int, w float64) edge {
+	if v < u {
+		u, v = v, u
+	}
+	return edge{u: u, v: v, w: w}
+}
+
+// less orders the edges by weight then by their lower and higher vertex
+func (s edge) less(e edge) bool {
+	if s.w != e.w {
+		return s.w < e.w
+	}
+	if s.u != e.u {
+		return s.u < e.u
+	}
+	return s.v < e.v
+}
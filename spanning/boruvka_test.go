@@ -0,0 +1,164 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package spanning_test
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/spanning"
+)
+
+func TestBoruvka(t *testing.T) {
+	tests := []struct {
+		name   string
+		g      [][]float64
+		forest [][]float64
+		total  float64
+	}{
+		{
+			name: "Tree",
+			g: [][]float64{
+				{0, 4, 0, 0, 0, 0, 0, 8, 0},
+				{4, 0, 8, 0, 0, 0, 0, 11, 0},
+				{0, 8, 0, 7, 0, 4, 0, 0, 2},
+				{0, 0, 7, 0, 9, 14, 0, 0, 0},
+				{0, 0, 0, 9, 0, 10, 0, 0, 0},
+				{0, 0, 4, 14, 10, 0, 2, 0, 0},
+				{0, 0, 0, 0, 0, 2, 0, 1, 6},
+				{8, 11, 0, 0, 0, 0, 1, 0, 7},
+				{0, 0, 2, 0, 0, 0, 6, 7, 0},
+			},
+			forest: [][]float64{
+				{0, 4, 0, 0, 0, 0, 0, 8, 0},
+				{4, 0, 0, 0, 0, 0, 0, 0, 0},
+				{0, 0, 0, 7, 0, 4, 0, 0, 2},
+				{0, 0, 7, 0, 9, 0, 0, 0, 0},
+				{0, 0, 0, 9, 0, 0, 0, 0, 0},
+				{0, 0, 4, 0, 0, 0, 2, 0, 0},
+				{0, 0, 0, 0, 0, 2, 0, 1, 0},
+				{8, 0, 0, 0, 0, 0, 1, 0, 0},
+				{0, 0, 2, 0, 0, 0, 0, 0, 0},
+			},
+			total: 37,
+		},
+		{
+			name: "Ties",
+			g: [][]float64{
+				{0, 1, 0, 1},
+				{1, 0, 1, 0},
+				{0, 1, 0, 1},
+				{1, 0, 1, 0},
+			},
+			forest: [][]float64{
+				{0, 1, 0, 1},
+				{1, 0, 1, 0},
+				{0, 1, 0, 0},
+				{1, 0, 0, 0},
+			},
+			total: 3,
+		},
+		{
+			name: "Forest",
+			g: [][]float64{
+				{0, 2, 0, 0, 0},
+				{2, 0, 0, 0, 0},
+				{0, 0, 0, 3, -1},
+				{0, 0, 3, 0, 5},
+				{0, 0, -1, 5, 0},
+			},
+			forest: [][]float64{
+				{0, 2, 0, 0, 0},
+				{2, 0, 0, 0, 0},
+				{0, 0, 0, 3, -1},
+				{0, 0, 3, 0, 0},
+				{0, 0, -1, 0, 0},
+			},
+			total: 4,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			forest, total, err := spanning.Boruvka(context.Background(), graphblas.NewCSRMatrixFromArray(tt.g))
+			if err != nil {
+				t.Fatalf("%+v Boruvka error %+v", tt.name, err)
+			}
+
+			if total != tt.total {
+				t.Errorf("%+v Boruvka total = %+v, want %+v", tt.name, total, tt.total)
+			}
+
+			for r := range tt.forest {
+				for c := range tt.forest[r] {
+					if forest.At(r, c) != tt.forest[r][c] {
+						t.Errorf("%+v Boruvka At(%+v, %+v) = %+v, want %+v", tt.name, r, c, forest.At(r, c), tt.forest[r][c])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestBoruvka_Random(t *testing.T) {
+	n := 80
+	rnd := rand.New(rand.NewSource(1))
+	g := graphblas.NewCSRMatrix[float64](n, n)
+
+	type edge struct {
+		u, v int
+		w    float64
+	}
+	edges := []edge{}
+	for i := 0; i < 300; i++ {
+		u, v := rnd.Intn(n), rnd.Intn(n)
+		if u == v || g.At(u, v) != 0 {
+			continue
+		}
+		w := float64(1 + rnd.Intn(10))
+		g.Set(u, v, w)
+		g.Set(v, u, w)
+		edges = append(edges, edge{u, v, w})
+	}
+
+	// Kruskal for the expected total
+	sort.SliceStable(edges, func(i, j int) bool { return edges[i].w < edges[j].w })
+	root := make([]int, n)
+	for i := range root {
+		root[i] = i
+	}
+	var find func(int) int
+	find = func(u int) int {
+		if root[u] != u {
+			root[u] = find(root[u])
+		}
+		return root[u]
+	}
+	want, count := 0.0, 0
+	for _, e := range edges {
+		if ru, rv := find(e.u), find(e.v); ru != rv {
+			root[ru] = rv
+			want += e.w
+			count++
+		}
+	}
+
+	forest, total, err := spanning.Boruvka(context.Background(), g)
+	if err != nil {
+		t.Fatalf("Boruvka error %+v", err)
+	}
+
+	if math.Abs(total-want) > 1e-9 {
+		t.Errorf("Boruvka total = %+v, want %+v", total, want)
+	}
+
+	if forest.Values() != 2*count {
+		t.Errorf("Boruvka Values = %+v, want %+v", forest.Values(), 2*count)
+	}
+}
@@ -7,6 +7,8 @@ package graphblas
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"log"
 	"reflect"
 
@@ -188,7 +190,7 @@ func (s *SparseVector[T]) remove(pointer int) {
 
 func (s *SparseVector[T]) index(i int) (int, int, error) {
 	length := len(s.indices)
-	if i > length {
+	if length == 0 || i > s.indices[length-1] {
 		return length, length, nil
 	}
 
@@ -369,3 +371,29 @@ func (s *sparseVectorMap[T]) Map(f func(int, int, T) T) {
 func (s *SparseVector[T]) Element(r, c int) bool {
 	return s.AtVec(r) > Default[T]()
 }
+
+// MarshalJSON encodes the vector in the sparse-vector JSON format
+func (s *SparseVector[T]) MarshalJSON() ([]byte, error) {
+	j := newJSONMatrix[T](s.l, 1, FormatSparseVector)
+	j.Indices = s.indices
+	j.Values = s.values
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON decodes the vector from the sparse-vector JSON format
+func (s *SparseVector[T]) UnmarshalJSON(data []byte) error {
+	j, err := unmarshalJSONMatrix[T](data, FormatSparseVector)
+	if err != nil {
+		return err
+	}
+
+	s.l = j.Rows
+	s.indices = j.Indices
+	s.values = j.Values
+	return nil
+}
+
+// Format implements fmt.Formatter, see format for the verbs supported
+func (s *SparseVector[T]) Format(f fmt.State, verb rune) {
+	format[T](f, verb, s, layoutPairs)
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package graphblas
+
+import (
+	"context"
+
+	"github.com/rossmerr/graphblas/binaryop"
+	"github.com/rossmerr/graphblas/constraints"
+)
+
+// StructureOptions the zero value keeps every edge of the matrix with its weight
+type StructureOptions struct {
+	// Transpose returns the structure of aᵀ
+	Transpose bool
+
+	// Symmetrize adds the reverse of each edge, the heavier of two opposite edges is kept in both directions
+	Symmetrize bool
+
+	// RemoveLoops drops the self-loops
+	RemoveLoops bool
+
+	// Pattern sets the weight of every edge to 1
+	Pattern bool
+}
+
+// Structure returns the edges of the adjacency matrix a as a CSRMatrix of U, the transpose is taken by TransposeToCSR
+func Structure[T, U constraints.Number](ctx context.Context, a Matrix[T], options StructureOptions) *CSRMatrix[U] {
+	if options.Transpose {
+		a = TransposeToCSR[T](ctx, a)
+	}
+
+	rows := []int{}
+	cols := []int{}
+	values := []U{}
+	for iterator := a.Enumerate(); iterator.HasNext() && ctx.Err() == nil; {
+		r, c, v := iterator.Next()
+		if IsZero(v) || (r == c && options.RemoveLoops) {
+			continue
+		}
+
+		value := U(v)
+		if options.Pattern {
+			value = 1
+		}
+
+		rows = append(rows, r)
+		cols = append(cols, c)
+		values = append(values, value)
+
+		if options.Symmetrize {
+			rows = append(rows, c)
+			cols = append(cols, r)
+			values = append(values, value)
+		}
+	}
+
+	var dup binaryop.BinaryOp[U]
+	if options.Symmetrize {
+		dup = binaryop.Maximum[U]()
+	}
+
+	return NewCSRMatrixFromTuples(a.Rows(), a.Columns(), rows, cols, values, dup)
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package graphblas_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rossmerr/graphblas"
+)
+
+func TestStructure(t *testing.T) {
+	a := graphblas.NewDenseMatrixFromArrayN([][]float64{
+		{2, 3, 0},
+		{0, 0, 5},
+		{1, 0, 0},
+	})
+
+	tests := []struct {
+		name    string
+		options graphblas.StructureOptions
+		want    [][]int
+	}{
+		{
+			name:    "Convert",
+			options: graphblas.StructureOptions{},
+			want:    [][]int{{2, 3, 0}, {0, 0, 5}, {1, 0, 0}},
+		},
+		{
+			name:    "Transpose",
+			options: graphblas.StructureOptions{Transpose: true},
+			want:    [][]int{{2, 0, 1}, {3, 0, 0}, {0, 5, 0}},
+		},
+		{
+			name:    "Symmetrize",
+			options: graphblas.StructureOptions{Symmetrize: true, RemoveLoops: true},
+			want:    [][]int{{0, 3, 1}, {3, 0, 5}, {1, 5, 0}},
+		},
+		{
+			name:    "Pattern",
+			options: graphblas.StructureOptions{Transpose: true, RemoveLoops: true, Pattern: true},
+			want:    [][]int{{0, 0, 1}, {1, 0, 0}, {0, 1, 0}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := graphblas.Structure[float64, int](context.Background(), a, tt.options)
+			want := graphblas.NewCSRMatrixFromArray(tt.want)
+			if !got.Equal(want) {
+				t.Errorf("%+v Structure = %+v, want %+v", tt.name, got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+// Package triangles counts the triangles of an undirected graph with masked matrix products
+package triangles
+
+import (
+	"context"
+	"sort"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/constraints"
+)
+
+// Method the formulation used to count the triangles
+type Method int
+
+const (
+	// SandiaLL sums (L·L) ⊙ L where L is the strictly lower triangle, each triangle is counted once
+	SandiaLL Method = iota
+
+	// Cohen sums (L·U) ⊙ A where U is the strictly upper triangle, each triangle is counted twice
+	Cohen
+
+	// Burkhardt sums (A·A) ⊙ A, each triangle is counted six times
+	Burkhardt
+)
+
+// Options the zero value counts with SandiaLL on a symmetric adjacency matrix without relabelling
+type Options struct {
+	// Method the formulation used to count the triangles, defaults to SandiaLL
+	Method Method
+
+	// Relabel numbers the vertices by increasing degree first, a high degree vertex then has most of its neighbours
+	// below it so a long row in L and a short one in U, row k of the right operand is read once per entry of column k
+	// of the left so L·L reads Σ |L(k)|·|U(k)| elements and L·U reads Σ |U(k)|², both far fewer on skewed degree
+	// distributions, Burkhardt reads Σ |A(k)|² whatever the order
+	Relabel bool
+
+	// Symmetrize treats a directed graph as undirected by adding the reverse of each edge
+	Symmetrize bool
+}
+
+// Count the triangles of the undirected graph with adjacency matrix a, self-loops and weights are ignored
+func Count[T constraints.Number](ctx context.Context, a graphblas.Matrix[T], options Options) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	s := graphblas.Structure[T, int](ctx, a, graphblas.StructureOptions{Symmetrize: options.Symmetrize, RemoveLoops: true, Pattern: true})
+	if options.Relabel {
+		s = relabel(s)
+	}
+	n := s.Rows()
+
+	var x, y, mask graphblas.Matrix[int]
+	divisor := 1
+
+	switch options.Method {
+	case Cohen:
+		l, u := split(s)
+		x, y, mask, divisor = l, u, s, 2
+	case Burkhardt:
+		x, y, mask, divisor = s, s, s, 6
+	default:
+		l, _ := split(s)
+		x, y, mask = l, l, l
+	}
+
+	// the complement of the mask skips every pair without an edge
+	c := graphblas.NewCSRMatrix[int](n, n)
+	graphblas.MatrixMatrixMultiply[int](ctx, x, y, graphblas.NewComplement(mask), c)
+
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	sum := graphblas.ReduceMatrixToScalarWithMonoID[int](ctx, c, graphblas.DefaultMonoIDAddition[int](), nil)
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	return sum / divisor, nil
+}
+
+// PerVertex the number of triangles through each vertex of the undirected graph with adjacency matrix a, counted with
+// Burkhardt as the row sums of (A·A) ⊙ A count each triangle through a vertex twice, the Method and Relabel options are ignored
+func PerVertex[T constraints.Number](ctx context.Context, a graphblas.Matrix[T], options Options) (graphblas.Vector[int], error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s := graphblas.Structure[T, int](ctx, a, graphblas.StructureOptions{Symmetrize: options.Symmetrize, RemoveLoops: true, Pattern: true})
+	n := s.Rows()
+
+	c := graphblas.NewCSRMatrix[int](n, n)
+	graphblas.MatrixMatrixMultiply[int](ctx, s, s, graphblas.NewComplement(s), c)
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// c is symmetric so reducing the columns reduces the rows
+	result := graphblas.ReduceMatrixToVectorWithMonoID[int](ctx, c, graphblas.DefaultMonoIDAddition[int](), nil)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	for iterator := result.Map(); iterator.HasNext(); {
+		iterator.Map(func(r, c int, v int) int {
+			return v / 2
+		})
+	}
+
+	return result, nil
+}
+
+// split returns the strictly lower and upper triangles of s
+func split(s *graphblas.CSRMatrix[int]) (l, u *graphblas.CSRMatrix[int]) {
+	n := s.Rows()
+	lRows, lCols, lValues := []int{}, []int{}, []int{}
+	uRows, uCols, uValues := []int{}, []int{}, []int{}
+	for iterator := s.Enumerate(); iterator.HasNext(); {
+		r, c, v := iterator.Next()
+		if r > c {
+			lRows, lCols, lValues = append(lRows, r), append(lCols, c), append(lValues, v)
+		} else {
+			uRows, uCols, uValues = append(uRows, r), append(uCols, c), append(uValues, v)
+		}
+	}
+
+	l = graphblas.NewCSRMatrixFromTuples(n, n, lRows, lCols, lValues, nil)
+	u = graphblas.NewCSRMatrixFromTuples(n, n, uRows, uCols, uValues, nil)
+	return l, u
+}
+
+// relabel numbers the vertices of s by increasing degree
+func relabel(s *graphblas.CSRMatrix[int]) *graphblas.CSRMatrix[int] {
+	n := s.Rows()
+	degree := make([]int, n)
+	for iterator := s.Enumerate(); iterator.HasNext(); {
+		r, _, _ := iterator.Next()
+		degree[r]++
+	}
+
+	order := make([]int, n)
+	for v := range order {
+		order[v] = v
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return degree[order[i]] < degree[order[j]]
+	})
+
+	label := make([]int, n)
+	for i, v := range order {
+		label[v] = i
+	}
+
+	rows := []int{}
+	cols := []int{}
+	values := []int{}
+	for iterator := s.Enumerate(); iterator.HasNext(); {
+		r, c, v := iterator.Next()
+		rows = append(rows, label[r])
+		cols = append(cols, label[c])
+		values = append(values, v)
+	}
+
+	return graphblas.NewCSRMatrixFromTuples(n, n, rows, cols, values, nil)
+}
@@ -0,0 +1,191 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package triangles_test
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/internal/cancel"
+	"github.com/rossmerr/graphblas/triangles"
+)
+
+// two triangles 0-1-2 and 2-3-4 sharing vertex 2 with a pendant vertex 5 off 4
+var undirected = [][]float64{
+	{0, 1, 1, 0, 0, 0},
+	{1, 0, 1, 0, 0, 0},
+	{1, 1, 0, 1, 1, 0},
+	{0, 0, 1, 0, 1, 0},
+	{0, 0, 1, 1, 0, 1},
+	{0, 0, 0, 0, 1, 0},
+}
+
+// the same graph with each edge in one direction only and a self-loop on 3
+var directed = [][]float64{
+	{0, 1, 1, 0, 0, 0},
+	{0, 0, 1, 0, 0, 0},
+	{0, 0, 0, 1, 1, 0},
+	{0, 0, 0, 1, 1, 0},
+	{0, 0, 0, 0, 0, 1},
+	{0, 0, 0, 0, 0, 0},
+}
+
+func TestCount(t *testing.T) {
+	// a random graph with its triangles counted by brute force
+	n := 40
+	rnd := rand.New(rand.NewSource(1))
+	random := graphblas.NewCSRMatrix[float64](n, n)
+	for i := 0; i < 200; i++ {
+		u, v := rnd.Intn(n), rnd.Intn(n)
+		if u != v {
+			random.Set(u, v, 1)
+			random.Set(v, u, 1)
+		}
+	}
+	want := 0
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			for k := j + 1; k < n; k++ {
+				if random.At(i, j) != 0 && random.At(j, k) != 0 && random.At(i, k) != 0 {
+					want++
+				}
+			}
+		}
+	}
+
+	tests := []struct {
+		name    string
+		s       graphblas.Matrix[float64]
+		options triangles.Options
+		want    int
+	}{
+		{
+			name: "SandiaLL",
+			s:    graphblas.NewCSRMatrixFromArray(undirected),
+			want: 2,
+		},
+		{
+			name:    "Cohen",
+			s:       graphblas.NewCSRMatrixFromArray(undirected),
+			options: triangles.Options{Method: triangles.Cohen},
+			want:    2,
+		},
+		{
+			name:    "Burkhardt",
+			s:       graphblas.NewDenseMatrixFromArrayN(undirected),
+			options: triangles.Options{Method: triangles.Burkhardt},
+			want:    2,
+		},
+		{
+			name:    "Symmetrize",
+			s:       graphblas.NewCSRMatrixFromArray(directed),
+			options: triangles.Options{Symmetrize: true},
+			want:    2,
+		},
+		{
+			name: "Random",
+			s:    random,
+			want: want,
+		},
+		{
+			name:    "RandomRelabel",
+			s:       random,
+			options: triangles.Options{Relabel: true},
+			want:    want,
+		},
+		{
+			name:    "RandomCohenRelabel",
+			s:       random,
+			options: triangles.Options{Method: triangles.Cohen, Relabel: true},
+			want:    want,
+		},
+		{
+			name:    "RandomBurkhardt",
+			s:       random,
+			options: triangles.Options{Method: triangles.Burkhardt},
+			want:    want,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := triangles.Count(context.Background(), tt.s, tt.options)
+			if err != nil {
+				t.Fatalf("%+v Count error %+v", tt.name, err)
+			}
+
+			if got != tt.want {
+				t.Errorf("%+v Count = %+v, want %+v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPerVertex(t *testing.T) {
+	want := []int{1, 1, 2, 1, 1, 0}
+
+	tests := []struct {
+		name    string
+		s       graphblas.Matrix[float64]
+		options triangles.Options
+	}{
+		{
+			name: "CSRMatrix",
+			s:    graphblas.NewCSRMatrixFromArray(undirected),
+		},
+		{
+			name:    "Symmetrize",
+			s:       graphblas.NewCSRMatrixFromArray(directed),
+			options: triangles.Options{Symmetrize: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := triangles.PerVertex(context.Background(), tt.s, tt.options)
+			if err != nil {
+				t.Fatalf("%+v PerVertex error %+v", tt.name, err)
+			}
+
+			for i, w := range want {
+				if got.AtVec(i) != w {
+					t.Errorf("%+v PerVertex AtVec(%+v) = %+v, want %+v", tt.name, i, got.AtVec(i), w)
+				}
+			}
+		})
+	}
+}
+
+func TestCount_Cancel(t *testing.T) {
+	a := graphblas.NewCSRMatrixFromArray(undirected)
+	tests := []struct {
+		name string
+		f    func(ctx context.Context) error
+	}{
+		{
+			name: "Count",
+			f: func(ctx context.Context) error {
+				_, err := triangles.Count[float64](ctx, a, triangles.Options{Method: triangles.Cohen, Relabel: true})
+				return err
+			},
+		},
+		{
+			name: "PerVertex",
+			f: func(ctx context.Context) error {
+				_, err := triangles.PerVertex[float64](ctx, a, triangles.Options{})
+				return err
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := cancel.Every(time.Second, tt.f); err != nil {
+				t.Errorf("%+v %+v", tt.name, err)
+			}
+		})
+	}
+}
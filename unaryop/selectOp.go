@@ -0,0 +1,28 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package unaryop
+
+import "github.com/rossmerr/graphblas/constraints"
+
+// SelectOp is a function that decides from its position and value whether an element is kept
+type SelectOp[T constraints.None] interface {
+	Apply(r, c int, value T) bool
+	Operator()
+}
+
+func NewSelectOp[T constraints.None](apply func(r, c int, value T) bool) SelectOp[T] {
+	return &selectOp[T]{apply: apply}
+}
+
+type selectOp[T constraints.None] struct {
+	apply func(r, c int, value T) bool
+}
+
+func (s *selectOp[T]) Operator() {}
+
+func (s *selectOp[T]) Apply(r, c int, value T) bool {
+	return s.apply(r, c, value)
+}